@@ -43,21 +43,21 @@ func (gc *GoogleController) HandleGoogleCallback(w http.ResponseWriter, r *http.
 	oauthState, _ := r.Cookie("oauthstate")
 
 	if r.FormValue("state") != oauthState.Value {
-		log.Error("invalid oauth google state")
+		log.WithContext(r.Context()).Error("invalid oauth google state")
 		http.Redirect(w, r, "/", http.StatusTemporaryRedirect)
 		return
 	}
 
 	data, err := gc.GoogleUseCase.GetUserDataFromGoogle(googleOauthConfig, r.FormValue("code"), oauthGoogleUrlAPI)
 	if err != nil {
-		log.Error(err)
+		log.WithContext(r.Context()).Error(err)
 		http.Redirect(w, r, "/", http.StatusTemporaryRedirect)
 		return
 	}
 
 	accessToken, refreshToken, err := gc.GoogleUseCase.GoogleLogin(ctx, data, gc.Env)
 	if err != nil {
-		log.Error(err)
+		log.WithContext(r.Context()).Error(err)
 		http.Redirect(w, r, "/", http.StatusTemporaryRedirect)
 		return
 	}
@@ -0,0 +1,82 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/iemran93/devMatch/bootstrap"
+	"github.com/iemran93/devMatch/internal/buildinfo"
+	"github.com/iemran93/devMatch/utils"
+	"github.com/jmoiron/sqlx"
+	log "github.com/sirupsen/logrus"
+)
+
+type HealthController struct {
+	DB               *sqlx.DB
+	Lifecycle        *bootstrap.Lifecycle
+	MigrationVersion uint
+	Timeout          time.Duration
+}
+
+type healthResponse struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks,omitempty"`
+}
+
+// Liveness only reports that the process is able to serve requests.
+func (hc *HealthController) Liveness(w http.ResponseWriter, r *http.Request) {
+	utils.JSON(w, http.StatusOK, healthResponse{Status: "ok"})
+}
+
+// Readiness checks the database connection and migration version, and fails
+// while the server is draining connections during shutdown.
+func (hc *HealthController) Readiness(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), hc.Timeout)
+	defer cancel()
+
+	checks := map[string]string{}
+	ready := true
+
+	if hc.Lifecycle.IsDraining() {
+		checks["lifecycle"] = "shutting down"
+		ready = false
+	} else {
+		checks["lifecycle"] = "ok"
+	}
+
+	if err := hc.DB.PingContext(ctx); err != nil {
+		log.WithContext(r.Context()).Error(err)
+		checks["database"] = "unreachable"
+		ready = false
+	} else {
+		checks["database"] = "ok"
+	}
+
+	version, dirty, err := utils.CurrentMigrationVersion(ctx, hc.DB)
+	switch {
+	case err != nil:
+		log.WithContext(r.Context()).Error(err)
+		checks["migrations"] = "unknown version"
+		ready = false
+	case dirty:
+		checks["migrations"] = fmt.Sprintf("version %d is dirty", version)
+		ready = false
+	case version != hc.MigrationVersion:
+		checks["migrations"] = fmt.Sprintf("version %d, expected %d", version, hc.MigrationVersion)
+		ready = false
+	default:
+		checks["migrations"] = "ok"
+	}
+
+	if !ready {
+		utils.JSON(w, http.StatusServiceUnavailable, healthResponse{Status: "unavailable", Checks: checks})
+		return
+	}
+	utils.JSON(w, http.StatusOK, healthResponse{Status: "ok", Checks: checks})
+}
+
+func (hc *HealthController) Version(w http.ResponseWriter, r *http.Request) {
+	utils.JSON(w, http.StatusOK, buildinfo.Get())
+}
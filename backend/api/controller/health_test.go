@@ -0,0 +1,158 @@
+package controller
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/iemran93/devMatch/bootstrap"
+	"github.com/iemran93/devMatch/internal/buildinfo"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeDB answers the ping and the migration version query of the readiness
+// check.
+type fakeDB struct {
+	pingErr error
+	version int64
+	dirty   bool
+}
+
+// fakeDB is its own connector, driver and connection.
+func (d *fakeDB) Connect(context.Context) (driver.Conn, error) { return d, nil }
+func (d *fakeDB) Driver() driver.Driver                        { return d }
+func (d *fakeDB) Open(string) (driver.Conn, error)             { return d, nil }
+func (d *fakeDB) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("not supported")
+}
+func (d *fakeDB) Close() error              { return nil }
+func (d *fakeDB) Begin() (driver.Tx, error) { return nil, errors.New("not supported") }
+func (d *fakeDB) Ping(context.Context) error {
+	return d.pingErr
+}
+func (d *fakeDB) QueryContext(context.Context, string, []driver.NamedValue) (driver.Rows, error) {
+	if d.pingErr != nil {
+		return nil, d.pingErr
+	}
+	return &fakeRows{values: []driver.Value{d.version, d.dirty}}, nil
+}
+
+type fakeRows struct {
+	values []driver.Value
+	done   bool
+}
+
+func (r *fakeRows) Columns() []string { return []string{"version", "dirty"} }
+func (r *fakeRows) Close() error      { return nil }
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+	r.done = true
+	copy(dest, r.values)
+	return nil
+}
+
+func newHealthController(t *testing.T, db *fakeDB, expected uint) *HealthController {
+	sqlDB := sql.OpenDB(db)
+	t.Cleanup(func() { sqlDB.Close() })
+	return &HealthController{
+		DB:               sqlx.NewDb(sqlDB, "mysql"),
+		Lifecycle:        bootstrap.NewLifecycle(),
+		MigrationVersion: expected,
+		Timeout:          time.Second,
+	}
+}
+
+func serve(handler http.HandlerFunc) (int, healthResponse) {
+	rec := httptest.NewRecorder()
+	handler(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	var body healthResponse
+	json.Unmarshal(rec.Body.Bytes(), &body)
+	return rec.Code, body
+}
+
+func TestHealthController_Liveness(t *testing.T) {
+	hc := newHealthController(t, &fakeDB{pingErr: errors.New("down")}, 16)
+
+	// liveness does not depend on the database
+	code, body := serve(hc.Liveness)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "ok", body.Status)
+}
+
+func TestHealthController_Readiness(t *testing.T) {
+	tests := []struct {
+		name     string
+		db       *fakeDB
+		draining bool
+		code     int
+		checks   map[string]string
+	}{
+		{
+			name:   "ready",
+			db:     &fakeDB{version: 16},
+			code:   http.StatusOK,
+			checks: map[string]string{"lifecycle": "ok", "database": "ok", "migrations": "ok"},
+		},
+		{
+			name:   "database down",
+			db:     &fakeDB{pingErr: errors.New("connection refused")},
+			code:   http.StatusServiceUnavailable,
+			checks: map[string]string{"lifecycle": "ok", "database": "unreachable", "migrations": "unknown version"},
+		},
+		{
+			name:   "migration mismatch",
+			db:     &fakeDB{version: 15},
+			code:   http.StatusServiceUnavailable,
+			checks: map[string]string{"lifecycle": "ok", "database": "ok", "migrations": "version 15, expected 16"},
+		},
+		{
+			name:   "dirty migration",
+			db:     &fakeDB{version: 16, dirty: true},
+			code:   http.StatusServiceUnavailable,
+			checks: map[string]string{"lifecycle": "ok", "database": "ok", "migrations": "version 16 is dirty"},
+		},
+		{
+			name:     "draining",
+			db:       &fakeDB{version: 16},
+			draining: true,
+			code:     http.StatusServiceUnavailable,
+			checks:   map[string]string{"lifecycle": "shutting down", "database": "ok", "migrations": "ok"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hc := newHealthController(t, tt.db, 16)
+			if tt.draining {
+				hc.Lifecycle.StartDraining()
+			}
+
+			code, body := serve(hc.Readiness)
+			assert.Equal(t, tt.code, code)
+			assert.Equal(t, tt.checks, body.Checks)
+		})
+	}
+}
+
+func TestHealthController_Version(t *testing.T) {
+	hc := newHealthController(t, &fakeDB{}, 16)
+
+	rec := httptest.NewRecorder()
+	hc.Version(rec, httptest.NewRequest(http.MethodGet, "/version", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var info buildinfo.Info
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &info))
+	assert.Equal(t, buildinfo.Get(), info)
+}
@@ -37,6 +37,6 @@ func (lc *LogoutController) Logout(w http.ResponseWriter, r *http.Request) {
 	}
 	http.SetCookie(w, expiredRefreshCookie)
 
-	log.Info("User logged out successfully")
+	log.WithContext(r.Context()).Info("User logged out successfully")
 	utils.JSON(w, http.StatusOK, domain.SuccessResponse{Message: "Logged out successfully"})
 }
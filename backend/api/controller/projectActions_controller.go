@@ -2,6 +2,8 @@ package controller
 
 import (
 	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
 	"strconv"
 
@@ -9,7 +11,6 @@ import (
 	"github.com/iemran93/devMatch/bootstrap"
 	"github.com/iemran93/devMatch/domain"
 	"github.com/iemran93/devMatch/utils"
-	log "github.com/sirupsen/logrus"
 )
 
 type ProjectActionsController struct {
@@ -21,67 +22,157 @@ func (c *ProjectActionsController) GetProjectRequests(w http.ResponseWriter, r *
 	vars := mux.Vars(r)
 	id, err := strconv.Atoi(vars["id"])
 	if err != nil {
-		log.Error(err)
-		utils.JSON(w, http.StatusBadRequest, domain.ErrorResponse{Message: "Invalid project ID"})
+		utils.Error(w, r, domain.ErrInvalidId)
 		return
 	}
 
 	ctx := r.Context()
 	projectRequests, err := c.ProjectActionsUseCase.GetById(ctx, id)
 	if err != nil {
-		log.Error(err)
-		utils.JSON(w, http.StatusInternalServerError, domain.ErrorResponse{Message: "Internal server error"})
+		utils.Error(w, r, err)
 		return
 	}
 
-	if projectRequests == nil {
-		utils.JSON(w, http.StatusNotFound, domain.ErrorResponse{Message: "Project not found"})
+	utils.JSON(w, http.StatusOK, projectRequests)
+}
+
+// ListApplicants accepts the filters role_id, status, shortlisted, tag,
+// min_rating and min_score and sorts by sort (created_at, match_score or
+// rating) in order (asc or desc).
+func (c *ProjectActionsController) ListApplicants(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.Error(w, r, domain.ErrInvalidId)
 		return
 	}
 
-	utils.JSON(w, http.StatusOK, projectRequests)
+	query := r.URL.Query()
+	filter := domain.ApplicantFilter{
+		Status: query.Get("status"),
+		Tag:    query.Get("tag"),
+		Sort:   query.Get("sort"),
+		Order:  query.Get("order"),
+	}
+	var fields []domain.FieldError
+	for _, param := range []struct {
+		name string
+		dst  *int
+	}{
+		{"role_id", &filter.RoleId},
+		{"min_rating", &filter.MinRating},
+		{"min_score", &filter.MinScore},
+	} {
+		if v := query.Get(param.name); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil {
+				fields = append(fields, domain.FieldError{Field: param.name, Rule: "number", Message: "must be a number"})
+				continue
+			}
+			*param.dst = n
+		}
+	}
+	if v := query.Get("shortlisted"); v != "" {
+		shortlisted, err := strconv.ParseBool(v)
+		if err != nil {
+			fields = append(fields, domain.FieldError{Field: "shortlisted", Rule: "boolean", Message: "must be true or false"})
+		} else {
+			filter.Shortlisted = &shortlisted
+		}
+	}
+	if len(fields) > 0 {
+		utils.Error(w, r, &domain.ValidationError{Errors: fields})
+		return
+	}
+	if err := filter.Validate(); err != nil {
+		utils.Error(w, r, err)
+		return
+	}
+
+	applicants, err := c.ProjectActionsUseCase.ListApplicants(r.Context(), id, filter)
+	if err != nil {
+		utils.Error(w, r, err)
+		return
+	}
+	utils.JSON(w, http.StatusOK, applicants)
+}
+
+func (c *ProjectActionsController) ReviewApplicant(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.Error(w, r, domain.ErrInvalidId)
+		return
+	}
+
+	var req domain.ReviewApplicantRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.Error(w, r, domain.ErrIncorrectRequestBody.WithMessage(err.Error()))
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		utils.Error(w, r, err)
+		return
+	}
+
+	review, err := c.ProjectActionsUseCase.ReviewApplicant(r.Context(), id, &req)
+	if err != nil {
+		utils.Error(w, r, err)
+		return
+	}
+	utils.JSON(w, http.StatusOK, review)
 }
 
 func (c *ProjectActionsController) ApplyToProject(w http.ResponseWriter, r *http.Request) {
 	var req domain.ProjectActionRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		log.Error(err)
-		utils.JSON(w, http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+		utils.Error(w, r, domain.ErrIncorrectRequestBody.WithMessage(err.Error()))
 		return
 	}
 
 	if err := req.Validate(); err != nil {
-		log.Error(err)
-		utils.JSON(w, http.StatusBadRequest, domain.ErrorResponse{Message: domain.ErrIncorrectRequestBody.Error()})
+		utils.Error(w, r, err)
 		return
 	}
 
 	ctx := r.Context()
-	if err := c.ProjectActionsUseCase.ApplyToProject(ctx, req); err != nil {
-		log.Error(err)
-		utils.JSON(w, http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
+	status, err := c.ProjectActionsUseCase.ApplyToProject(ctx, req)
+	if err != nil {
+		utils.Error(w, r, err)
+		return
+	}
+	if status == domain.RequestWaitlisted {
+		utils.JSON(w, http.StatusOK, domain.SuccessResponse{Message: "The role is filled, you joined its waitlist"})
 		return
 	}
 	utils.JSON(w, http.StatusOK, domain.SuccessResponse{Message: "Applied to project successfully"})
 }
+
+// ApplicationUsage returns the user's open applications and cooldowns
+// against the application limits.
+func (c *ProjectActionsController) ApplicationUsage(w http.ResponseWriter, r *http.Request) {
+	usage, err := c.ProjectActionsUseCase.ApplicationUsage(r.Context())
+	if err != nil {
+		utils.Error(w, r, err)
+		return
+	}
+	utils.JSON(w, http.StatusOK, usage)
+}
+
 func (c *ProjectActionsController) CancelRequestToProject(w http.ResponseWriter, r *http.Request) {
 	var req domain.ProjectActionRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		log.Error(err)
-		utils.JSON(w, http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+		utils.Error(w, r, domain.ErrIncorrectRequestBody.WithMessage(err.Error()))
 		return
 	}
 
 	if err := req.Validate(); err != nil {
-		log.Error(err)
-		utils.JSON(w, http.StatusBadRequest, domain.ErrorResponse{Message: domain.ErrIncorrectRequestBody.Error()})
+		utils.Error(w, r, err)
 		return
 	}
 
 	ctx := r.Context()
 	if err := c.ProjectActionsUseCase.CancelRequestToProject(ctx, req); err != nil {
-		log.Error(err)
-		utils.JSON(w, http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
+		utils.Error(w, r, err)
 		return
 	}
 	utils.JSON(w, http.StatusOK, domain.SuccessResponse{Message: "Request cancelled successfully"})
@@ -89,21 +180,18 @@ func (c *ProjectActionsController) CancelRequestToProject(w http.ResponseWriter,
 func (c *ProjectActionsController) WithdrawFromProject(w http.ResponseWriter, r *http.Request) {
 	var req domain.ProjectActionRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		log.Error(err)
-		utils.JSON(w, http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+		utils.Error(w, r, domain.ErrIncorrectRequestBody.WithMessage(err.Error()))
 		return
 	}
 
 	if err := req.Validate(); err != nil {
-		log.Error(err)
-		utils.JSON(w, http.StatusBadRequest, domain.ErrorResponse{Message: domain.ErrIncorrectRequestBody.Error()})
+		utils.Error(w, r, err)
 		return
 	}
 
 	ctx := r.Context()
 	if err := c.ProjectActionsUseCase.WithdrawFromProject(ctx, req); err != nil {
-		log.Error(err)
-		utils.JSON(w, http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
+		utils.Error(w, r, err)
 		return
 	}
 	utils.JSON(w, http.StatusOK, domain.SuccessResponse{Message: "Withdrawn from project successfully"})
@@ -111,22 +199,115 @@ func (c *ProjectActionsController) WithdrawFromProject(w http.ResponseWriter, r
 func (c *ProjectActionsController) ReplyToRequest(w http.ResponseWriter, r *http.Request) {
 	var req domain.ProjectActionReplyRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		log.Error(err)
-		utils.JSON(w, http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+		utils.Error(w, r, domain.ErrIncorrectRequestBody.WithMessage(err.Error()))
 		return
 	}
 
 	if err := req.Validate(); err != nil {
-		log.Error(err)
-		utils.JSON(w, http.StatusBadRequest, domain.ErrorResponse{Message: domain.ErrIncorrectRequestBody.Error()})
+		utils.Error(w, r, err)
 		return
 	}
 
 	ctx := r.Context()
 	if err := c.ProjectActionsUseCase.ReplyToRequest(ctx, req); err != nil {
-		log.Error(err)
-		utils.JSON(w, http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
+		utils.Error(w, r, err)
 		return
 	}
 	utils.JSON(w, http.StatusOK, domain.SuccessResponse{Message: "Replied to request successfully"})
 }
+
+// RemoveMember takes an optional body with the reason for the removal.
+func (c *ProjectActionsController) RemoveMember(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.Error(w, r, domain.ErrInvalidId)
+		return
+	}
+
+	var req domain.RemoveMemberRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		utils.Error(w, r, domain.ErrIncorrectRequestBody.WithMessage(err.Error()))
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		utils.Error(w, r, err)
+		return
+	}
+
+	if err := c.ProjectActionsUseCase.RemoveMember(r.Context(), id, &req); err != nil {
+		utils.Error(w, r, err)
+		return
+	}
+	utils.JSON(w, http.StatusOK, domain.SuccessResponse{Message: "Member removed successfully"})
+}
+
+func (c *ProjectActionsController) Invite(w http.ResponseWriter, r *http.Request) {
+	var req domain.InviteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.Error(w, r, domain.ErrIncorrectRequestBody.WithMessage(err.Error()))
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		utils.Error(w, r, err)
+		return
+	}
+
+	invitation, err := c.ProjectActionsUseCase.Invite(r.Context(), &req)
+	if err != nil {
+		utils.Error(w, r, err)
+		return
+	}
+	utils.JSON(w, http.StatusCreated, invitation)
+}
+
+func (c *ProjectActionsController) ListInvitations(w http.ResponseWriter, r *http.Request) {
+	invitations, err := c.ProjectActionsUseCase.ListInvitations(r.Context())
+	if err != nil {
+		utils.Error(w, r, err)
+		return
+	}
+	utils.JSON(w, http.StatusOK, invitations)
+}
+
+func (c *ProjectActionsController) AcceptInvitation(w http.ResponseWriter, r *http.Request) {
+	c.respondToInvitation(w, r, true)
+}
+
+func (c *ProjectActionsController) DeclineInvitation(w http.ResponseWriter, r *http.Request) {
+	c.respondToInvitation(w, r, false)
+}
+
+func (c *ProjectActionsController) respondToInvitation(w http.ResponseWriter, r *http.Request, accept bool) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.Error(w, r, domain.ErrInvalidId)
+		return
+	}
+
+	if err := c.ProjectActionsUseCase.RespondToInvitation(r.Context(), id, accept); err != nil {
+		utils.Error(w, r, err)
+		return
+	}
+
+	message := "Invitation declined successfully"
+	if accept {
+		message = "Invitation accepted successfully"
+	}
+	utils.JSON(w, http.StatusOK, domain.SuccessResponse{Message: message})
+}
+
+func (c *ProjectActionsController) CancelInvitation(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.Error(w, r, domain.ErrInvalidId)
+		return
+	}
+
+	if err := c.ProjectActionsUseCase.CancelInvitation(r.Context(), id); err != nil {
+		utils.Error(w, r, err)
+		return
+	}
+	utils.JSON(w, http.StatusOK, domain.SuccessResponse{Message: "Invitation cancelled successfully"})
+}
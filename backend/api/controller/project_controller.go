@@ -2,14 +2,15 @@ package controller
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/gorilla/mux"
 	"github.com/iemran93/devMatch/bootstrap"
 	"github.com/iemran93/devMatch/domain"
 	"github.com/iemran93/devMatch/utils"
-	log "github.com/sirupsen/logrus"
 )
 
 type ProjectController struct {
@@ -20,22 +21,19 @@ type ProjectController struct {
 func (pc *ProjectController) Create(w http.ResponseWriter, r *http.Request) {
 	var req domain.CreateProjectRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		log.Error(err)
-		utils.JSON(w, http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+		utils.Error(w, r, domain.ErrIncorrectRequestBody.WithMessage(err.Error()))
 		return
 	}
 
 	if err := req.Validate(); err != nil {
-		log.Error(err)
-		utils.JSON(w, http.StatusBadRequest, domain.ErrorResponse{Message: domain.ErrIncorrectRequestBody.Error()})
+		utils.Error(w, r, err)
 		return
 	}
 
 	ctx := r.Context()
 	project, err := pc.ProjectUseCase.Create(ctx, &req)
 	if err != nil {
-		log.Error(err)
-		utils.JSON(w, http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
+		utils.Error(w, r, err)
 		return
 	}
 
@@ -46,25 +44,23 @@ func (pc *ProjectController) GetById(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	id, err := strconv.Atoi(vars["id"])
 	if err != nil {
-		log.Error(err)
-		utils.JSON(w, http.StatusBadRequest, domain.ErrorResponse{Message: "Invalid project ID"})
+		utils.Error(w, r, domain.ErrInvalidId)
 		return
 	}
 
 	ctx := r.Context()
 	project, err := pc.ProjectUseCase.GetById(ctx, id)
 	if err != nil {
-		log.Error(err)
-		utils.JSON(w, http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
+		utils.Error(w, r, err)
 		return
 	}
 
 	if project == nil {
-		utils.JSON(w, http.StatusNotFound, domain.ErrorResponse{Message: "Project not found"})
+		utils.Error(w, r, domain.ErrProjectNotFound)
 		return
 	}
 
-	utils.JSON(w, http.StatusOK, project)
+	utils.CachedJSON(w, r, utils.CacheRevalidate, project)
 }
 
 func (pc *ProjectController) List(w http.ResponseWriter, r *http.Request) {
@@ -84,72 +80,182 @@ func (pc *ProjectController) List(w http.ResponseWriter, r *http.Request) {
 			filters["category_id"] = categoryId
 		}
 	}
+	// role filters match projects with an open role asking for them
+	if technology := r.URL.Query().Get("role_technology_id"); technology != "" {
+		if technologyId, err := strconv.Atoi(technology); err == nil {
+			filters["role_technology_id"] = technologyId
+		}
+	}
+	if language := r.URL.Query().Get("role_language_id"); language != "" {
+		if languageId, err := strconv.Atoi(language); err == nil {
+			filters["role_language_id"] = languageId
+		}
+	}
+	if level := r.URL.Query().Get("experience_level"); level != "" {
+		filters["experience_level"] = level
+	}
 
 	ctx := r.Context()
 	projects, err := pc.ProjectUseCase.List(ctx, filters)
 	if err != nil {
-		log.Error(err)
-		utils.JSON(w, http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
+		utils.Error(w, r, err)
 		return
 	}
 
-	utils.JSON(w, http.StatusOK, projects)
+	utils.CachedJSON(w, r, utils.CacheRevalidate, projects)
 }
 
 func (pc *ProjectController) Update(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	id, err := strconv.Atoi(vars["id"])
 	if err != nil {
-		log.Error(err)
-		utils.JSON(w, http.StatusBadRequest, domain.ErrorResponse{Message: "Invalid project ID"})
+		utils.Error(w, r, domain.ErrInvalidId)
 		return
 	}
 
 	var req domain.UpdateProjectRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		log.Error(err)
-		utils.JSON(w, http.StatusBadRequest, domain.ErrIncorrectRequestBody)
+		utils.Error(w, r, domain.ErrIncorrectRequestBody.WithMessage(err.Error()))
 		return
 	}
 
 	err = req.Validate()
 	if err != nil {
-		log.Error(err)
-		utils.JSON(w, http.StatusBadRequest, domain.ErrIncorrectRequestBody)
+		utils.Error(w, r, err)
 		return
 	}
-	log.Warn(req)
 	ctx := r.Context()
-	if err := pc.ProjectUseCase.Update(ctx, &req, id); err != nil {
-		if err == domain.ErrUnauthorized {
-			utils.JSON(w, http.StatusForbidden, domain.ErrUnauthorized)
-			return
-		}
-		log.Error(err)
-		utils.JSON(w, http.StatusInternalServerError, domain.ErrInternalServerError)
+
+	matched, ok := pc.checkIfMatch(w, r, id)
+	if !ok {
+		return
+	}
+	if err := ifMatchVersion(req.Version, matched); err != nil {
+		utils.Error(w, r, err)
+		return
+	}
+
+	project, err := pc.ProjectUseCase.Update(ctx, &req, id)
+	if err != nil {
+		utils.Error(w, r, preconditionError(err, matched))
 		return
 	}
 
-	utils.JSON(w, http.StatusOK, "Project updated successfully")
+	if etag, err := utils.ETag(project); err == nil {
+		w.Header().Set("ETag", etag)
+	}
+	utils.JSON(w, http.StatusOK, project)
+}
+
+func (pc *ProjectController) Patch(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		utils.Error(w, r, domain.ErrInvalidId)
+		return
+	}
+
+	var req domain.PatchProjectRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.Error(w, r, domain.ErrIncorrectRequestBody.WithMessage(err.Error()))
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		utils.Error(w, r, err)
+		return
+	}
+
+	matched, ok := pc.checkIfMatch(w, r, id)
+	if !ok {
+		return
+	}
+	if err := ifMatchVersion(req.Version, matched); err != nil {
+		utils.Error(w, r, err)
+		return
+	}
+
+	project, err := pc.ProjectUseCase.Patch(r.Context(), &req, id)
+	if err != nil {
+		utils.Error(w, r, preconditionError(err, matched))
+		return
+	}
+
+	if etag, err := utils.ETag(project); err == nil {
+		w.Header().Set("ETag", etag)
+	}
+	utils.JSON(w, http.StatusOK, project)
+}
+
+// checkIfMatch guards against overwriting an edit the client has not seen.
+// It returns the version of the representation the If-Match ETag names, 0
+// without a header or for "*". The caller sends that version to the
+// repository, whose UPDATE only applies while the row still has it, so a
+// write committed after this check cannot be overwritten.
+func (pc *ProjectController) checkIfMatch(w http.ResponseWriter, r *http.Request, id int) (int, bool) {
+	ifMatch := r.Header.Get("If-Match")
+	if ifMatch == "" {
+		return 0, true
+	}
+
+	current, err := pc.ProjectUseCase.GetById(r.Context(), id)
+	if err != nil {
+		utils.Error(w, r, err)
+		return 0, false
+	}
+	if current == nil {
+		utils.Error(w, r, domain.ErrProjectNotFound)
+		return 0, false
+	}
+	if strings.TrimSpace(ifMatch) == "*" {
+		return 0, true
+	}
+
+	etag, err := utils.ETag(current)
+	if err != nil {
+		utils.Error(w, r, err)
+		return 0, false
+	}
+	if !utils.MatchETag(ifMatch, etag, false) {
+		w.Header().Set("ETag", etag)
+		utils.Error(w, r, domain.ErrPreconditionFailed)
+		return 0, false
+	}
+	return current.Version, true
+}
+
+// ifMatchVersion pins the version of an update to the one If-Match named.
+// A body version that disagrees with it fails the precondition.
+func ifMatchVersion(bodyVersion int, matched int) error {
+	if matched == 0 {
+		return nil
+	}
+	if bodyVersion != matched {
+		return domain.ErrPreconditionFailed.WithMessage("version does not match If-Match")
+	}
+	return nil
+}
+
+// preconditionError reports a lost race as 412 when the client sent
+// If-Match, like a failed ETag comparison.
+func preconditionError(err error, matched int) error {
+	if matched != 0 && errors.Is(err, domain.ErrVersionConflict) {
+		return domain.ErrPreconditionFailed
+	}
+	return err
 }
 
 func (pc *ProjectController) Delete(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	id, err := strconv.Atoi(vars["id"])
 	if err != nil {
-		log.Error(err)
-		utils.JSON(w, http.StatusBadRequest, domain.ErrorResponse{Message: "Invalid project ID"})
+		utils.Error(w, r, domain.ErrInvalidId)
 		return
 	}
 
 	ctx := r.Context()
 	if err := pc.ProjectUseCase.Delete(ctx, id); err != nil {
-		if err == domain.ErrUnauthorized {
-			utils.JSON(w, http.StatusForbidden, domain.ErrorResponse{Message: "Not authorized to delete this project"})
-			return
-		}
-		log.Error(err)
-		utils.JSON(w, http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
+		utils.Error(w, r, err)
 		return
 	}
 
@@ -157,47 +263,43 @@ func (pc *ProjectController) Delete(w http.ResponseWriter, r *http.Request) {
 }
 
 func (pc *ProjectController) GetCategory(w http.ResponseWriter, r *http.Request) {
-	categories, err := pc.ProjectUseCase.GetCategory(r.Context())
+	categories, err := pc.ProjectUseCase.GetCategory(r.Context(), r.URL.Query().Get("q"))
 	if err != nil {
-		log.Error(err)
-		utils.JSON(w, http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
+		utils.Error(w, r, err)
 		return
 	}
 
-	utils.JSON(w, http.StatusOK, categories)
+	utils.CachedJSON(w, r, utils.CacheTaxonomy, categories)
 }
 
 func (pc *ProjectController) GetTechnology(w http.ResponseWriter, r *http.Request) {
-	technologies, err := pc.ProjectUseCase.GetTechnology(r.Context())
+	technologies, err := pc.ProjectUseCase.GetTechnology(r.Context(), r.URL.Query().Get("q"))
 	if err != nil {
-		log.Error(err)
-		utils.JSON(w, http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
+		utils.Error(w, r, err)
 		return
 	}
 
-	utils.JSON(w, http.StatusOK, technologies)
+	utils.CachedJSON(w, r, utils.CacheTaxonomy, technologies)
 }
 
 func (pc *ProjectController) GetLanguage(w http.ResponseWriter, r *http.Request) {
-	languages, err := pc.ProjectUseCase.GetLanguage(r.Context())
+	languages, err := pc.ProjectUseCase.GetLanguage(r.Context(), r.URL.Query().Get("q"))
 	if err != nil {
-		log.Error(err)
-		utils.JSON(w, http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
+		utils.Error(w, r, err)
 		return
 	}
 
-	utils.JSON(w, http.StatusOK, languages)
+	utils.CachedJSON(w, r, utils.CacheTaxonomy, languages)
 }
 
 func (pc *ProjectController) GetType(w http.ResponseWriter, r *http.Request) {
-	types, err := pc.ProjectUseCase.GetType(r.Context())
+	types, err := pc.ProjectUseCase.GetType(r.Context(), r.URL.Query().Get("q"))
 	if err != nil {
-		log.Error(err)
-		utils.JSON(w, http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
+		utils.Error(w, r, err)
 		return
 	}
 
-	utils.JSON(w, http.StatusOK, types)
+	utils.CachedJSON(w, r, utils.CacheTaxonomy, types)
 }
 
 func (pc *ProjectController) GetProjectRoles(w http.ResponseWriter, r *http.Request) {
@@ -206,18 +308,16 @@ func (pc *ProjectController) GetProjectRoles(w http.ResponseWriter, r *http.Requ
 	vars := mux.Vars(r)
 	id, err := strconv.Atoi(vars["id"])
 	if err != nil {
-		log.Error(err)
-		utils.JSON(w, http.StatusBadRequest, domain.ErrIncorrectRequestBody)
+		utils.Error(w, r, domain.ErrInvalidId)
 		return
 	}
 
 	roles, err := pc.ProjectUseCase.GetByProjectId(ctx, id)
 	if err != nil {
-		log.Error(err)
-		utils.JSON(w, http.StatusInternalServerError, domain.ErrInternalServerError)
+		utils.Error(w, r, err)
 		return
 	}
 
-	utils.JSON(w, http.StatusOK, roles)
+	utils.CachedJSON(w, r, utils.CacheRevalidate, roles)
 
 }
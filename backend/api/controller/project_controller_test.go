@@ -0,0 +1,96 @@
+package controller
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/iemran93/devMatch/domain"
+	"github.com/iemran93/devMatch/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockProjectUseCase mocks the reads and writes of project updates; calling
+// any other method panics on the nil embedded interface.
+type MockProjectUseCase struct {
+	mock.Mock
+	domain.ProjectUseCase
+}
+
+func (m *MockProjectUseCase) GetById(ctx context.Context, id int) (*domain.ProjectResponse, error) {
+	args := m.Called(ctx, id)
+	project, _ := args.Get(0).(*domain.ProjectResponse)
+	return project, args.Error(1)
+}
+
+func (m *MockProjectUseCase) Update(ctx context.Context, req *domain.UpdateProjectRequest, id int) (*domain.ProjectResponse, error) {
+	args := m.Called(ctx, req, id)
+	project, _ := args.Get(0).(*domain.ProjectResponse)
+	return project, args.Error(1)
+}
+
+func TestProjectController_UpdateIfMatch(t *testing.T) {
+	current := &domain.ProjectResponse{Id: 1, Title: "devMatch", Version: 3}
+	etag, err := utils.ETag(current)
+	require.NoError(t, err)
+
+	update := func(uc *MockProjectUseCase, version int, ifMatch string) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(domain.UpdateProjectRequest{
+			Version: version, Title: "devMatch", Description: "d", CategoryId: 1, Stage: "Idea",
+			ProjectType: []int{1}, Technologies: []int{1}, Languages: []int{1},
+		})
+		req := httptest.NewRequest(http.MethodPut, "/api/projects/1", bytes.NewReader(body))
+		req.Header.Set("If-Match", ifMatch)
+		req = mux.SetURLVars(req, map[string]string{"id": "1"})
+		rec := httptest.NewRecorder()
+		(&ProjectController{ProjectUseCase: uc}).Update(rec, req)
+		return rec
+	}
+
+	t.Run("matching version", func(t *testing.T) {
+		uc := new(MockProjectUseCase)
+		uc.On("GetById", mock.Anything, 1).Return(current, nil)
+		uc.On("Update", mock.Anything, mock.MatchedBy(func(req *domain.UpdateProjectRequest) bool {
+			return req.Version == 3
+		}), 1).Return(&domain.ProjectResponse{Id: 1, Title: "devMatch", Version: 4}, nil)
+
+		rec := update(uc, 3, etag)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		uc.AssertExpectations(t)
+	})
+
+	t.Run("body version disagrees with If-Match", func(t *testing.T) {
+		uc := new(MockProjectUseCase)
+		uc.On("GetById", mock.Anything, 1).Return(current, nil)
+
+		rec := update(uc, 2, etag)
+		assert.Equal(t, http.StatusPreconditionFailed, rec.Code)
+		uc.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("written after the check", func(t *testing.T) {
+		uc := new(MockProjectUseCase)
+		uc.On("GetById", mock.Anything, 1).Return(current, nil)
+		// the conditional UPDATE found another version
+		uc.On("Update", mock.Anything, mock.Anything, 1).Return(nil, domain.ErrVersionConflict)
+
+		rec := update(uc, 3, etag)
+		assert.Equal(t, http.StatusPreconditionFailed, rec.Code)
+	})
+
+	t.Run("stale ETag", func(t *testing.T) {
+		uc := new(MockProjectUseCase)
+		uc.On("GetById", mock.Anything, 1).Return(current, nil)
+
+		rec := update(uc, 3, `"stale"`)
+		assert.Equal(t, http.StatusPreconditionFailed, rec.Code)
+		assert.Equal(t, etag, rec.Header().Get("ETag"))
+		uc.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -9,7 +9,6 @@ import (
 	"github.com/iemran93/devMatch/bootstrap"
 	"github.com/iemran93/devMatch/domain"
 	"github.com/iemran93/devMatch/utils"
-	log "github.com/sirupsen/logrus"
 )
 
 type ProjectRolesController struct {
@@ -22,21 +21,18 @@ func (prc *ProjectRolesController) CreateRole(w http.ResponseWriter, r *http.Req
 	ctx := r.Context()
 
 	if err := json.NewDecoder(r.Body).Decode(&projectRoleRequest); err != nil {
-		log.Error(err)
-		utils.JSON(w, http.StatusBadRequest, domain.ErrIncorrectRequestBody)
+		utils.Error(w, r, domain.ErrIncorrectRequestBody.WithMessage(err.Error()))
 		return
 	}
 
 	if err := projectRoleRequest.Validate(); err != nil {
-		log.Error(err)
-		utils.JSON(w, http.StatusBadRequest, domain.ErrIncorrectRequestBody)
+		utils.Error(w, r, err)
 		return
 	}
 
 	role, err := prc.ProjectRolesUseCase.Create(ctx, &projectRoleRequest)
 	if err != nil {
-		log.Error(err)
-		utils.JSON(w, http.StatusInternalServerError, domain.ErrInternalServerError)
+		utils.Error(w, r, err)
 		return
 	}
 
@@ -49,27 +45,23 @@ func (prc *ProjectRolesController) UpdateRole(w http.ResponseWriter, r *http.Req
 	vars := mux.Vars(r)
 	id, err := strconv.Atoi(vars["id"])
 	if err != nil {
-		log.Error(err)
-		utils.JSON(w, http.StatusBadRequest, domain.ErrIncorrectRequestBody)
+		utils.Error(w, r, domain.ErrInvalidId)
 		return
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&projectRoleRequest); err != nil {
-		log.Error(err)
-		utils.JSON(w, http.StatusBadRequest, domain.ErrIncorrectRequestBody)
+		utils.Error(w, r, domain.ErrIncorrectRequestBody.WithMessage(err.Error()))
 		return
 	}
 
-	if err := projectRoleRequest.Validate(); err != nil {
-		log.Error(err)
-		utils.JSON(w, http.StatusBadRequest, domain.ErrIncorrectRequestBody)
+	if err := projectRoleRequest.ValidateUpdate(); err != nil {
+		utils.Error(w, r, err)
 		return
 	}
 
 	role, err := prc.ProjectRolesUseCase.Update(ctx, &projectRoleRequest, id)
 	if err != nil {
-		log.Error(err)
-		utils.JSON(w, http.StatusInternalServerError, domain.ErrInternalServerError)
+		utils.Error(w, r, err)
 		return
 	}
 
@@ -82,18 +74,113 @@ func (prc *ProjectRolesController) DeleteRole(w http.ResponseWriter, r *http.Req
 	vars := mux.Vars(r)
 	id, err := strconv.Atoi(vars["id"])
 	if err != nil {
-		log.Error(err)
-		utils.JSON(w, http.StatusBadRequest, domain.ErrIncorrectRequestBody)
+		utils.Error(w, r, domain.ErrInvalidId)
 		return
 	}
 
 	err = prc.ProjectRolesUseCase.Delete(ctx, id)
 	if err != nil {
-		log.Error(err)
-		utils.JSON(w, http.StatusInternalServerError, domain.ErrInternalServerError)
+		utils.Error(w, r, err)
 		return
 	}
 
 	utils.JSON(w, http.StatusOK, domain.SuccessResponse{Message: "Delete Role successfully"})
 
 }
+
+func (prc *ProjectRolesController) ListQuestions(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.Error(w, r, domain.ErrInvalidId)
+		return
+	}
+
+	questions, err := prc.ProjectRolesUseCase.ListQuestions(r.Context(), id)
+	if err != nil {
+		utils.Error(w, r, err)
+		return
+	}
+
+	utils.JSON(w, http.StatusOK, questions)
+}
+
+func (prc *ProjectRolesController) CreateQuestion(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.Error(w, r, domain.ErrInvalidId)
+		return
+	}
+
+	var req domain.RoleQuestionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.Error(w, r, domain.ErrIncorrectRequestBody.WithMessage(err.Error()))
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		utils.Error(w, r, err)
+		return
+	}
+
+	question, err := prc.ProjectRolesUseCase.CreateQuestion(r.Context(), id, &req)
+	if err != nil {
+		utils.Error(w, r, err)
+		return
+	}
+
+	utils.JSON(w, http.StatusCreated, question)
+}
+
+func (prc *ProjectRolesController) UpdateQuestion(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		utils.Error(w, r, domain.ErrInvalidId)
+		return
+	}
+	questionId, err := strconv.Atoi(vars["questionId"])
+	if err != nil {
+		utils.Error(w, r, domain.ErrInvalidId)
+		return
+	}
+
+	var req domain.RoleQuestionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.Error(w, r, domain.ErrIncorrectRequestBody.WithMessage(err.Error()))
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		utils.Error(w, r, err)
+		return
+	}
+
+	question, err := prc.ProjectRolesUseCase.UpdateQuestion(r.Context(), id, questionId, &req)
+	if err != nil {
+		utils.Error(w, r, err)
+		return
+	}
+
+	utils.JSON(w, http.StatusOK, question)
+}
+
+func (prc *ProjectRolesController) DeleteQuestion(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		utils.Error(w, r, domain.ErrInvalidId)
+		return
+	}
+	questionId, err := strconv.Atoi(vars["questionId"])
+	if err != nil {
+		utils.Error(w, r, domain.ErrInvalidId)
+		return
+	}
+
+	if err := prc.ProjectRolesUseCase.DeleteQuestion(r.Context(), id, questionId); err != nil {
+		utils.Error(w, r, err)
+		return
+	}
+
+	utils.JSON(w, http.StatusOK, domain.SuccessResponse{Message: "Question deleted successfully"})
+}
@@ -21,8 +21,8 @@ func (rtc *RefreshTokenController) RefreshToken(w http.ResponseWriter, r *http.R
 	refreshTokenValue, err := utils.GetCookie(r, "refresh_token")
 	if err != nil {
 		// Instead of an error, just return unauthorized status
-		log.Warn("Refresh token not found in cookies")
-		utils.JSON(w, http.StatusUnauthorized, domain.ErrorResponse{Message: "Authentication required"})
+		log.WithContext(r.Context()).Warn("Refresh token not found in cookies")
+		utils.Error(w, r, domain.ErrUnauthorized.WithMessage("Authentication required"))
 		return
 	}
 
@@ -31,16 +31,15 @@ func (rtc *RefreshTokenController) RefreshToken(w http.ResponseWriter, r *http.R
 	}
 
 	if err := request.Validate(); err != nil {
-		log.Error(err)
-		utils.JSON(w, http.StatusBadRequest, domain.ErrorResponse{Message: domain.ErrIncorrectRequestBody.Error()})
+		utils.Error(w, r, err)
 		return
 	}
 
 	// Generate new tokens
 	accessToken, refreshToken, err := rtc.RefreshTokenUseCase.RefreshToken(ctx, request, rtc.Env)
 	if err != nil {
-		log.Error(err)
-		utils.JSON(w, http.StatusUnauthorized, domain.ErrorResponse{Message: "Invalid refresh token"})
+		log.WithContext(r.Context()).Error(err)
+		utils.Error(w, r, domain.ErrInvalidToken.WithMessage("Invalid refresh token"))
 		return
 	}
 
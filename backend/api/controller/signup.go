@@ -7,8 +7,6 @@ import (
 	"github.com/iemran93/devMatch/bootstrap"
 	"github.com/iemran93/devMatch/domain"
 	"github.com/iemran93/devMatch/utils"
-
-	log "github.com/sirupsen/logrus"
 )
 
 type SignupController struct {
@@ -21,21 +19,18 @@ func (sc *SignupController) Signup(w http.ResponseWriter, r *http.Request) {
 	var request domain.SignupRequest
 
 	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
-		log.Error(err)
-		utils.JSON(w, http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+		utils.Error(w, r, domain.ErrIncorrectRequestBody.WithMessage(err.Error()))
 		return
 	}
 
 	if err := request.Validate(); err != nil {
-		log.Error(err)
-		utils.JSON(w, http.StatusBadRequest, domain.ErrorResponse{Message: domain.ErrIncorrectRequestBody.Error()})
+		utils.Error(w, r, err)
 		return
 	}
 
 	accessToken, refreshToken, err := sc.SignupUseCase.SignUp(ctx, request, sc.Env)
 	if err != nil {
-		log.Error(err)
-		utils.JSON(w, http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+		utils.Error(w, r, err)
 		return
 	}
 
@@ -0,0 +1,233 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/iemran93/devMatch/bootstrap"
+	"github.com/iemran93/devMatch/domain"
+	"github.com/iemran93/devMatch/utils"
+)
+
+type TaxonomyController struct {
+	TaxonomyUseCase domain.TaxonomyUseCase
+	Env             *bootstrap.Env
+}
+
+func (tc *TaxonomyController) List(w http.ResponseWriter, r *http.Request) {
+	kind := domain.TaxonomyKind(mux.Vars(r)["kind"])
+
+	entries, err := tc.TaxonomyUseCase.List(r.Context(), kind)
+	if err != nil {
+		utils.Error(w, r, err)
+		return
+	}
+
+	utils.JSON(w, http.StatusOK, entries)
+}
+
+func (tc *TaxonomyController) Create(w http.ResponseWriter, r *http.Request) {
+	kind := domain.TaxonomyKind(mux.Vars(r)["kind"])
+
+	var req domain.CreateTaxonomyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.Error(w, r, domain.ErrIncorrectRequestBody.WithMessage(err.Error()))
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		utils.Error(w, r, err)
+		return
+	}
+
+	entry, err := tc.TaxonomyUseCase.Create(r.Context(), kind, &req)
+	if err != nil {
+		utils.Error(w, r, err)
+		return
+	}
+
+	utils.JSON(w, http.StatusCreated, entry)
+}
+
+func (tc *TaxonomyController) Update(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	kind := domain.TaxonomyKind(vars["kind"])
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		utils.Error(w, r, domain.ErrInvalidId)
+		return
+	}
+
+	var req domain.UpdateTaxonomyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.Error(w, r, domain.ErrIncorrectRequestBody.WithMessage(err.Error()))
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		utils.Error(w, r, err)
+		return
+	}
+
+	entry, err := tc.TaxonomyUseCase.Update(r.Context(), kind, id, &req)
+	if err != nil {
+		utils.Error(w, r, err)
+		return
+	}
+
+	utils.JSON(w, http.StatusOK, entry)
+}
+
+func (tc *TaxonomyController) Merge(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	kind := domain.TaxonomyKind(vars["kind"])
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		utils.Error(w, r, domain.ErrInvalidId)
+		return
+	}
+
+	var req domain.MergeTaxonomyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.Error(w, r, domain.ErrIncorrectRequestBody.WithMessage(err.Error()))
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		utils.Error(w, r, err)
+		return
+	}
+
+	if err := tc.TaxonomyUseCase.Merge(r.Context(), kind, id, &req); err != nil {
+		utils.Error(w, r, err)
+		return
+	}
+
+	utils.JSON(w, http.StatusOK, domain.SuccessResponse{Message: "Merged successfully"})
+}
+
+func (tc *TaxonomyController) ListAliases(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	kind := domain.TaxonomyKind(vars["kind"])
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		utils.Error(w, r, domain.ErrInvalidId)
+		return
+	}
+
+	aliases, err := tc.TaxonomyUseCase.ListAliases(r.Context(), kind, id)
+	if err != nil {
+		utils.Error(w, r, err)
+		return
+	}
+
+	utils.JSON(w, http.StatusOK, aliases)
+}
+
+func (tc *TaxonomyController) CreateAlias(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	kind := domain.TaxonomyKind(vars["kind"])
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		utils.Error(w, r, domain.ErrInvalidId)
+		return
+	}
+
+	var req domain.CreateTaxonomyAliasRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.Error(w, r, domain.ErrIncorrectRequestBody.WithMessage(err.Error()))
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		utils.Error(w, r, err)
+		return
+	}
+
+	alias, err := tc.TaxonomyUseCase.CreateAlias(r.Context(), kind, id, &req)
+	if err != nil {
+		utils.Error(w, r, err)
+		return
+	}
+
+	utils.JSON(w, http.StatusCreated, alias)
+}
+
+func (tc *TaxonomyController) DeleteAlias(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	kind := domain.TaxonomyKind(vars["kind"])
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		utils.Error(w, r, domain.ErrInvalidId)
+		return
+	}
+	aliasId, err := strconv.Atoi(vars["aliasId"])
+	if err != nil {
+		utils.Error(w, r, domain.ErrInvalidId)
+		return
+	}
+
+	if err := tc.TaxonomyUseCase.DeleteAlias(r.Context(), kind, id, aliasId); err != nil {
+		utils.Error(w, r, err)
+		return
+	}
+
+	utils.JSON(w, http.StatusOK, domain.SuccessResponse{Message: "Alias deleted successfully"})
+}
+
+func (tc *TaxonomyController) ProposeTechnology(w http.ResponseWriter, r *http.Request) {
+	var req domain.ProposeTechnologyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.Error(w, r, domain.ErrIncorrectRequestBody.WithMessage(err.Error()))
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		utils.Error(w, r, err)
+		return
+	}
+
+	proposal, err := tc.TaxonomyUseCase.ProposeTechnology(r.Context(), &req)
+	if err != nil {
+		utils.Error(w, r, err)
+		return
+	}
+
+	utils.JSON(w, http.StatusCreated, proposal)
+}
+
+func (tc *TaxonomyController) ListProposals(w http.ResponseWriter, r *http.Request) {
+	proposals, err := tc.TaxonomyUseCase.ListProposals(r.Context(), r.URL.Query().Get("status"))
+	if err != nil {
+		utils.Error(w, r, err)
+		return
+	}
+
+	utils.JSON(w, http.StatusOK, proposals)
+}
+
+func (tc *TaxonomyController) ApproveProposal(w http.ResponseWriter, r *http.Request) {
+	tc.reviewProposal(w, r, true)
+}
+
+func (tc *TaxonomyController) RejectProposal(w http.ResponseWriter, r *http.Request) {
+	tc.reviewProposal(w, r, false)
+}
+
+func (tc *TaxonomyController) reviewProposal(w http.ResponseWriter, r *http.Request, approve bool) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.Error(w, r, domain.ErrInvalidId)
+		return
+	}
+
+	proposal, err := tc.TaxonomyUseCase.ReviewProposal(r.Context(), id, approve)
+	if err != nil {
+		utils.Error(w, r, err)
+		return
+	}
+
+	utils.JSON(w, http.StatusOK, proposal)
+}
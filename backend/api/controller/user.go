@@ -10,8 +10,6 @@ import (
 	"github.com/iemran93/devMatch/bootstrap"
 	"github.com/iemran93/devMatch/domain"
 	"github.com/iemran93/devMatch/utils"
-
-	log "github.com/sirupsen/logrus"
 )
 
 type UserController struct {
@@ -24,8 +22,7 @@ func (uc *UserController) GetUsers(w http.ResponseWriter, r *http.Request) {
 
 	users, err := uc.UserUseCase.GetUsers(ctx)
 	if err != nil {
-		log.Error(err)
-		utils.JSON(w, http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+		utils.Error(w, r, err)
 		return
 	}
 
@@ -40,15 +37,13 @@ func (uc *UserController) GetUserById(w http.ResponseWriter, r *http.Request) {
 
 	intId, err := strconv.Atoi(id)
 	if err != nil {
-		log.Error(err)
-		utils.JSON(w, http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+		utils.Error(w, r, domain.ErrUnauthorized)
 		return
 	}
 
 	user, err := uc.UserUseCase.GetUserById(ctx, intId)
 	if err != nil {
-		log.Error(err)
-		utils.JSON(w, http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+		utils.Error(w, r, err)
 		return
 	}
 
@@ -60,8 +55,7 @@ func (uc *UserController) UpdateUser(w http.ResponseWriter, r *http.Request) {
 
 	var user *domain.User
 	if err := json.NewDecoder(r.Body).Decode(&user); err != nil {
-		log.Error(err)
-		utils.JSON(w, http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+		utils.Error(w, r, domain.ErrIncorrectRequestBody.WithMessage(err.Error()))
 		return
 	}
 
@@ -69,8 +63,7 @@ func (uc *UserController) UpdateUser(w http.ResponseWriter, r *http.Request) {
 
 	userId, err := strconv.Atoi(id)
 	if err != nil {
-		log.Error(err)
-		utils.JSON(w, http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+		utils.Error(w, r, domain.ErrUnauthorized)
 		return
 	}
 
@@ -78,8 +71,7 @@ func (uc *UserController) UpdateUser(w http.ResponseWriter, r *http.Request) {
 
 	err = uc.UserUseCase.UpdateUser(ctx, user)
 	if err != nil {
-		log.Error(err)
-		utils.JSON(w, http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+		utils.Error(w, r, err)
 		return
 	}
 
@@ -92,15 +84,13 @@ func (uc *UserController) DeleteUser(w http.ResponseWriter, r *http.Request) {
 
 	id, err := strconv.Atoi(fmt.Sprintf("%v", ctx.Value("user_id")))
 	if err != nil {
-		log.Error(err)
-		utils.JSON(w, http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+		utils.Error(w, r, domain.ErrUnauthorized)
 		return
 	}
 
 	err = uc.UserUseCase.DeleteUser(ctx, id)
 	if err != nil {
-		log.Error(err)
-		utils.JSON(w, http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+		utils.Error(w, r, err)
 		return
 	}
 
@@ -0,0 +1,35 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/iemran93/devMatch/domain"
+	"github.com/iemran93/devMatch/utils"
+)
+
+// AdminMiddleware lets only admins through. It must run after
+// JwtAuthMiddleware, which puts the user id into the context. The flag is
+// read on every request so revoking it takes effect without a new token.
+func AdminMiddleware(users domain.UserUseCase) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			userId, ok := r.Context().Value("user_id").(int)
+			if !ok {
+				utils.Error(w, r, domain.ErrUnauthorized)
+				return
+			}
+
+			user, err := users.GetUserById(r.Context(), userId)
+			if err != nil {
+				utils.Error(w, r, err)
+				return
+			}
+			if !user.IsAdmin {
+				utils.Error(w, r, domain.ErrUserNotAllowed)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -17,25 +17,26 @@ func JwtAuthMiddleware(secret string) func(next http.Handler) http.Handler {
 				if err == nil {
 					authorized, err := tokenutil.IsAuthorized(authToken, secret)
 					if err != nil {
-						utils.JSON(w, 401, domain.ErrorResponse{Message: err.Error()})
+						utils.Error(w, r, domain.ErrInvalidToken)
 						return
 					}
 					if authorized {
 						userID, err := tokenutil.ExtractIDFromToken(authToken, secret)
 						if err != nil {
-							utils.JSON(w, 401, domain.ErrorResponse{Message: err.Error()})
+							utils.Error(w, r, domain.ErrInvalidToken)
 							return
 						}
 						// set user id to context
+						setLoggedUser(r.Context(), userID)
 						ctx := context.WithValue(r.Context(), "user_id", userID)
 						r = r.WithContext(ctx)
 						next.ServeHTTP(w, r)
 						return
 					}
-					utils.JSON(w, 401, domain.ErrorResponse{Message: domain.ErrUnauthorized.Error()})
+					utils.Error(w, r, domain.ErrUnauthorized)
 					return
 				}
-				utils.JSON(w, 401, domain.ErrorResponse{Message: domain.ErrUnauthorized.Error()})
+				utils.Error(w, r, domain.ErrUnauthorized)
 			})
 	}
 }
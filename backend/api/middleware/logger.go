@@ -1,14 +1,69 @@
 package middleware
 
 import (
+	"context"
+	"net"
 	"net/http"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 )
 
+type loggedUserKey struct{}
+
+// loggedUser is filled by JwtAuthMiddleware so the request line names the
+// user even though the logger runs first.
+type loggedUser struct {
+	id *int
+}
+
+// setLoggedUser records the authenticated user for LoggerMiddleware.
+func setLoggedUser(ctx context.Context, userID int) {
+	if u, ok := ctx.Value(loggedUserKey{}).(*loggedUser); ok {
+		u.id = &userID
+	}
+}
+
+// LoggerMiddleware logs one line per request. Request and user ids are taken
+// from the request context by the logrus context hook, so it must run after
+// RequestIDMiddleware. On protected routes it runs before JwtAuthMiddleware,
+// so rejected tokens are logged too, and picks up the user id afterwards.
 func LoggerMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		log.Info(r.Method, " ", r.RequestURI)
-		next.ServeHTTP(w, r)
+		start := time.Now()
+		rec := newResponseRecorder(w)
+		user := &loggedUser{}
+
+		next.ServeHTTP(rec, r.WithContext(context.WithValue(r.Context(), loggedUserKey{}, user)))
+
+		ctx := r.Context()
+		if user.id != nil {
+			ctx = context.WithValue(ctx, "user_id", *user.id)
+		}
+		entry := log.WithContext(ctx).WithFields(log.Fields{
+			"method":     r.Method,
+			"uri":        r.RequestURI,
+			"status":     rec.status,
+			"size":       rec.size,
+			"latency_ms": float64(time.Since(start).Microseconds()) / 1000,
+			"remote_ip":  remoteIP(r),
+		})
+
+		switch {
+		case rec.status >= http.StatusInternalServerError:
+			entry.Error("request completed")
+		case rec.status >= http.StatusBadRequest:
+			entry.Warn("request completed")
+		default:
+			entry.Info("request completed")
+		}
 	})
 }
+
+func remoteIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
@@ -0,0 +1,49 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/iemran93/devMatch/domain"
+	"github.com/iemran93/devMatch/internal/tokenutil"
+	log "github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoggerMiddleware_ProtectedRoutes(t *testing.T) {
+	hook := test.NewGlobal()
+	t.Cleanup(func() { log.StandardLogger().ReplaceHooks(make(log.LevelHooks)) })
+
+	const secret = "secret"
+	handler := RequestIDMiddleware(LoggerMiddleware(JwtAuthMiddleware(secret)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))))
+
+	// a request rejected by the JWT check is logged
+	req := httptest.NewRequest(http.MethodGet, "/api/user", nil)
+	req.Header.Set(requestIDHeader, "rejected-1")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	entry := hook.LastEntry()
+	require.NotNil(t, entry)
+	assert.Equal(t, log.WarnLevel, entry.Level)
+	assert.Equal(t, http.StatusUnauthorized, entry.Data["status"])
+	assert.Equal(t, "rejected-1", entry.Context.Value("request_id"))
+	assert.Nil(t, entry.Context.Value("user_id"))
+
+	// an authorized request is logged with its user
+	token, err := tokenutil.CreateAccessToken(&domain.User{Id: 42}, secret, 1)
+	require.NoError(t, err)
+	req = httptest.NewRequest(http.MethodGet, "/api/user", nil)
+	req.AddCookie(&http.Cookie{Name: "access_token", Value: token})
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	entry = hook.LastEntry()
+	require.NotNil(t, entry)
+	assert.Equal(t, log.InfoLevel, entry.Level)
+	assert.Equal(t, http.StatusOK, entry.Data["status"])
+	assert.Equal(t, 42, entry.Context.Value("user_id"))
+}
@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetricsTokenMiddleware(t *testing.T) {
+	handler := MetricsTokenMiddleware("secret")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	do := func(auth string) int {
+		req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+		if auth != "" {
+			req.Header.Set("Authorization", auth)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	assert.Equal(t, http.StatusUnauthorized, do(""))
+	assert.Equal(t, http.StatusUnauthorized, do("Bearer wrong"))
+	assert.Equal(t, http.StatusUnauthorized, do("secret"))
+	assert.Equal(t, http.StatusOK, do("Bearer secret"))
+}
@@ -0,0 +1,182 @@
+package middleware
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/iemran93/devMatch/domain"
+	"github.com/iemran93/devMatch/utils"
+)
+
+// RateLimitPolicy describes a token bucket: Burst tokens at most, refilled at
+// PerMinute tokens per minute.
+type RateLimitPolicy struct {
+	Name      string
+	PerMinute int
+	Burst     int
+}
+
+func (p RateLimitPolicy) rate() float64 {
+	return float64(p.PerMinute) / 60
+}
+
+type rateLimitGroup struct {
+	policy RateLimitPolicy
+	paths  []string
+}
+
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+	fullAt time.Time
+}
+
+// RateLimiter keeps one token bucket per client and policy. Clients are
+// identified by the authenticated user id when present, otherwise by IP.
+type RateLimiter struct {
+	mu         sync.Mutex
+	fallback   RateLimitPolicy
+	groups     []rateLimitGroup
+	buckets    map[string]*tokenBucket
+	trustProxy bool
+	lastSweep  time.Time
+	now        func() time.Time
+}
+
+func NewRateLimiter(fallback RateLimitPolicy, trustProxy bool) *RateLimiter {
+	return &RateLimiter{
+		fallback:   fallback,
+		buckets:    make(map[string]*tokenBucket),
+		trustProxy: trustProxy,
+		now:        time.Now,
+	}
+}
+
+// Group applies policy to the given route templates. A template also covers
+// every route nested below it, e.g. "/api/projects" covers "/api/projects/{id}".
+// Groups are matched in the order they were added.
+func (rl *RateLimiter) Group(policy RateLimitPolicy, paths ...string) *RateLimiter {
+	rl.groups = append(rl.groups, rateLimitGroup{policy: policy, paths: paths})
+	return rl
+}
+
+func (rl *RateLimiter) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		policy := rl.policyFor(r)
+		if policy.PerMinute <= 0 || policy.Burst <= 0 {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		allowed, remaining, retryAfter, reset := rl.take(policy, rl.clientKey(r))
+
+		h := w.Header()
+		h.Set("RateLimit-Limit", strconv.Itoa(policy.Burst))
+		h.Set("RateLimit-Remaining", strconv.Itoa(remaining))
+		h.Set("RateLimit-Reset", strconv.Itoa(ceilSeconds(reset)))
+		h.Set("RateLimit-Policy", fmt.Sprintf("%d;w=60;burst=%d", policy.PerMinute, policy.Burst))
+
+		if !allowed {
+			h.Set("Retry-After", strconv.Itoa(ceilSeconds(retryAfter)))
+			utils.Error(w, r, domain.ErrTooManyRequests)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (rl *RateLimiter) policyFor(r *http.Request) RateLimitPolicy {
+	path := r.URL.Path
+	if route := mux.CurrentRoute(r); route != nil {
+		if tpl, err := route.GetPathTemplate(); err == nil {
+			path = tpl
+		}
+	}
+
+	for _, g := range rl.groups {
+		for _, p := range g.paths {
+			if path == p || strings.HasPrefix(path, p+"/") {
+				return g.policy
+			}
+		}
+	}
+	return rl.fallback
+}
+
+func (rl *RateLimiter) clientKey(r *http.Request) string {
+	if userId, ok := r.Context().Value("user_id").(int); ok {
+		return "user:" + strconv.Itoa(userId)
+	}
+
+	if rl.trustProxy {
+		if fwd := r.Header.Get("X-Forwarded-For"); fwd != "" {
+			return "ip:" + strings.TrimSpace(strings.Split(fwd, ",")[0])
+		}
+	}
+
+	return "ip:" + remoteIP(r)
+}
+
+// take consumes one token from the client's bucket for policy.
+func (rl *RateLimiter) take(policy RateLimitPolicy, client string) (allowed bool, remaining int, retryAfter, reset time.Duration) {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	now := rl.now()
+	rl.sweep(now)
+
+	rate := policy.rate()
+	burst := float64(policy.Burst)
+
+	key := policy.Name + "|" + client
+	b, ok := rl.buckets[key]
+	if !ok {
+		b = &tokenBucket{tokens: burst, last: now}
+		rl.buckets[key] = b
+	}
+
+	b.tokens = math.Min(burst, b.tokens+now.Sub(b.last).Seconds()*rate)
+	b.last = now
+
+	if b.tokens >= 1 {
+		b.tokens--
+		allowed = true
+	} else {
+		retryAfter = secondsToDuration((1 - b.tokens) / rate)
+	}
+
+	remaining = int(b.tokens)
+	reset = secondsToDuration((burst - b.tokens) / rate)
+	b.fullAt = now.Add(reset)
+	return
+}
+
+// sweep drops buckets that have refilled completely; a fresh bucket is
+// equivalent, so memory does not grow with every client ever seen.
+func (rl *RateLimiter) sweep(now time.Time) {
+	if now.Sub(rl.lastSweep) < time.Minute {
+		return
+	}
+	rl.lastSweep = now
+
+	for key, b := range rl.buckets {
+		if !now.Before(b.fullAt) {
+			delete(rl.buckets, key)
+		}
+	}
+}
+
+func secondsToDuration(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second))
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
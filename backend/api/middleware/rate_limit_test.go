@@ -0,0 +1,61 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimiter_BlocksWhenBucketIsEmpty(t *testing.T) {
+	now := time.Now()
+	rl := NewRateLimiter(RateLimitPolicy{Name: "default", PerMinute: 60, Burst: 2}, false)
+	rl.now = func() time.Time { return now }
+
+	handler := rl.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	do := func() *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/projects", nil)
+		req.RemoteAddr = "10.0.0.1:1234"
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	assert.Equal(t, http.StatusOK, do().Code)
+	rec := do()
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "0", rec.Header().Get("RateLimit-Remaining"))
+
+	rec = do()
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Equal(t, "1", rec.Header().Get("Retry-After"))
+
+	// one token is refilled after a second
+	now = now.Add(time.Second)
+	assert.Equal(t, http.StatusOK, do().Code)
+}
+
+func TestRateLimiter_GroupsAndClientsHaveSeparateBuckets(t *testing.T) {
+	rl := NewRateLimiter(RateLimitPolicy{Name: "default", PerMinute: 60, Burst: 5}, false).
+		Group(RateLimitPolicy{Name: "auth", PerMinute: 1, Burst: 1}, "/api/login")
+
+	handler := rl.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	do := func(path, addr string) int {
+		req := httptest.NewRequest(http.MethodPost, path, nil)
+		req.RemoteAddr = addr
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	assert.Equal(t, http.StatusOK, do("/api/login", "10.0.0.1:1"))
+	assert.Equal(t, http.StatusTooManyRequests, do("/api/login", "10.0.0.1:2"))
+	assert.Equal(t, http.StatusOK, do("/api/login", "10.0.0.2:1"))
+	assert.Equal(t, http.StatusOK, do("/api/projects", "10.0.0.1:1"))
+}
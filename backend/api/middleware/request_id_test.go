@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestIDMiddleware(t *testing.T) {
+	var seen string
+	handler := RequestIDMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen, _ = r.Context().Value("request_id").(string)
+	}))
+
+	do := func(requestID string) string {
+		req := httptest.NewRequest(http.MethodGet, "/api/projects", nil)
+		if requestID != "" {
+			req.Header.Set(requestIDHeader, requestID)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		assert.Equal(t, seen, rec.Header().Get(requestIDHeader))
+		return seen
+	}
+
+	// an incoming id is propagated
+	assert.Equal(t, "abc-123_x.y", do("abc-123_x.y"))
+
+	// a missing id is generated
+	generated := do("")
+	assert.Len(t, generated, 32)
+	assert.NotEqual(t, generated, do(""))
+
+	// malformed ids are replaced
+	for _, bad := range []string{"a b", "a\nlevel=error", "<script>", strings.Repeat("a", 129)} {
+		got := do(bad)
+		assert.NotEqual(t, bad, got)
+		assert.Len(t, got, 32)
+	}
+}
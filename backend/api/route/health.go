@@ -0,0 +1,34 @@
+package route
+
+import (
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/iemran93/devMatch/api/controller"
+	"github.com/iemran93/devMatch/bootstrap"
+	"github.com/iemran93/devMatch/utils"
+	"github.com/jmoiron/sqlx"
+	log "github.com/sirupsen/logrus"
+)
+
+// NewHealthRouter registers the probe endpoints on the root router, outside
+// /api so they are neither rate limited nor logged.
+func NewHealthRouter(env *bootstrap.Env, timeout time.Duration, db *sqlx.DB, lifecycle *bootstrap.Lifecycle, r *mux.Router) {
+	version, err := utils.LatestMigrationVersion(env)
+	if err != nil {
+		// readiness compares against this version, so it cannot start
+		// without it
+		log.Fatal("error while reading migration files: ", err)
+	}
+
+	hc := &controller.HealthController{
+		DB:               db,
+		Lifecycle:        lifecycle,
+		MigrationVersion: version,
+		Timeout:          timeout,
+	}
+
+	r.HandleFunc("/healthz", hc.Liveness).Methods("GET")
+	r.HandleFunc("/readyz", hc.Readiness).Methods("GET")
+	r.HandleFunc("/version", hc.Version).Methods("GET")
+}
@@ -0,0 +1,14 @@
+package route
+
+import (
+	"github.com/gorilla/mux"
+	"github.com/iemran93/devMatch/api/middleware"
+	"github.com/iemran93/devMatch/bootstrap"
+	"github.com/iemran93/devMatch/internal/metrics"
+)
+
+// NewMetricsRouter registers /metrics behind METRICS_TOKEN, either on the
+// root router or on the router of the METRICS_ADDR listener.
+func NewMetricsRouter(env *bootstrap.Env, r *mux.Router) {
+	r.Handle("/metrics", middleware.MetricsTokenMiddleware(env.MetricsToken)(metrics.Handler())).Methods("GET")
+}
@@ -8,11 +8,9 @@ import (
 	"github.com/iemran93/devMatch/bootstrap"
 	"github.com/iemran93/devMatch/repository"
 	"github.com/iemran93/devMatch/usecase"
-	"github.com/jmoiron/sqlx"
 )
 
-func NewProjectRouter(env *bootstrap.Env, timeout time.Duration, db *sqlx.DB, publicRouter, protectedRouter *mux.Router) {
-	pr := repository.NewProjectRepository(db)
+func NewProjectRouter(env *bootstrap.Env, timeout time.Duration, pr repository.ProjectRepository, publicRouter, protectedRouter *mux.Router) {
 	pu := usecase.NewProjectUseCase(pr, timeout)
 	pc := &controller.ProjectController{
 		ProjectUseCase: pu,
@@ -43,6 +41,7 @@ func setupPublicProjectRoutes(controller *controller.ProjectController, router *
 func setupProtectedProjectRoutes(controller *controller.ProjectController, router *mux.Router) {
 	router.HandleFunc("/projects", controller.Create).Methods("POST")
 	router.HandleFunc("/projects/{id}", controller.Update).Methods("PUT")
+	router.HandleFunc("/projects/{id}", controller.Patch).Methods("PATCH")
 	router.HandleFunc("/projects/{id}", controller.Delete).Methods("DELETE")
 	// Role route
 	// TODO: Implement these handlers in ProjectController
@@ -11,20 +11,37 @@ import (
 	"github.com/jmoiron/sqlx"
 )
 
-func NewProjectActionsRouter(env *bootstrap.Env, timeout time.Duration, db *sqlx.DB, r *mux.Router) {
+func NewProjectActionsRouter(env *bootstrap.Env, timeout time.Duration, db *sqlx.DB, prr repository.ProjectRepository, r *mux.Router) {
 	pr := repository.NewProjectActionsRepository(db)
+	rr := repository.NewProjectRolesRepository(db)
 	ur := repository.NewUserRepository(db)
-	prr := repository.NewProjectRepository(db)
-	pu := usecase.NewProjectActionsUseCase(pr, ur, prr, timeout)
+	nr := repository.NewNotificationRepository(db)
+	pu := usecase.NewProjectActionsUseCase(pr, rr, ur, prr, nr, timeout, usecase.ProjectActionsConfig{
+		InvitationTTL:       time.Duration(env.InvitationTTLHours) * time.Hour,
+		WaitlistAutoPromote: env.WaitlistAutoPromote,
+		MaxOpenPerUser:      env.ApplyMaxPendingPerUser,
+		MaxOpenPerRole:      env.ApplyMaxPendingPerRole,
+		RejectionCooldown:   time.Duration(env.ApplyRejectionCooldownHours) * time.Hour,
+	})
 	pc := &controller.ProjectActionsController{
 		ProjectActionsUseCase: pu,
 		Env:                   env,
 	}
 
 	group := r.PathPrefix("/project/request").Subrouter()
-	group.HandleFunc("/{id}", pc.GetProjectRequests).Methods("GET")
+	group.HandleFunc("/{id:[0-9]+}", pc.GetProjectRequests).Methods("GET")
+	group.HandleFunc("/{id:[0-9]+}/applicants", pc.ListApplicants).Methods("GET")
+	group.HandleFunc("/applicants/{id:[0-9]+}", pc.ReviewApplicant).Methods("PATCH")
 	group.HandleFunc("/apply", pc.ApplyToProject).Methods("POST")
+	group.HandleFunc("/usage", pc.ApplicationUsage).Methods("GET")
 	group.HandleFunc("/cancel", pc.CancelRequestToProject).Methods("DELETE")
 	group.HandleFunc("/withdraw", pc.WithdrawFromProject).Methods("DELETE")
 	group.HandleFunc("/reply", pc.ReplyToRequest).Methods("PUT")
+	group.HandleFunc("/members/{id:[0-9]+}", pc.RemoveMember).Methods("DELETE")
+
+	group.HandleFunc("/invite", pc.Invite).Methods("POST")
+	group.HandleFunc("/invitations", pc.ListInvitations).Methods("GET")
+	group.HandleFunc("/invitations/{id}/accept", pc.AcceptInvitation).Methods("PUT")
+	group.HandleFunc("/invitations/{id}/decline", pc.DeclineInvitation).Methods("PUT")
+	group.HandleFunc("/invitations/{id}", pc.CancelInvitation).Methods("DELETE")
 }
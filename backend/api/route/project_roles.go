@@ -11,9 +11,8 @@ import (
 	"github.com/jmoiron/sqlx"
 )
 
-func NewProjectRolesRouter(env *bootstrap.Env, timeout time.Duration, db *sqlx.DB, r *mux.Router) {
+func NewProjectRolesRouter(env *bootstrap.Env, timeout time.Duration, db *sqlx.DB, pr repository.ProjectRepository, r *mux.Router) {
 	prr := repository.NewProjectRolesRepository(db)
-	pr := repository.NewProjectRepository(db)
 	prc := &controller.ProjectRolesController{
 		ProjectRolesUseCase: usecase.NewProjectRolesUseCase(prr, pr, timeout),
 		Env:                 env,
@@ -23,4 +22,9 @@ func NewProjectRolesRouter(env *bootstrap.Env, timeout time.Duration, db *sqlx.D
 	group.HandleFunc("", prc.CreateRole).Methods("POST")
 	group.HandleFunc("/{id}", prc.UpdateRole).Methods("PUT")
 	group.HandleFunc("/{id}", prc.DeleteRole).Methods("DELETE")
+
+	group.HandleFunc("/{id}/questions", prc.ListQuestions).Methods("GET")
+	group.HandleFunc("/{id}/questions", prc.CreateQuestion).Methods("POST")
+	group.HandleFunc("/{id}/questions/{questionId}", prc.UpdateQuestion).Methods("PUT")
+	group.HandleFunc("/{id}/questions/{questionId}", prc.DeleteQuestion).Methods("DELETE")
 }
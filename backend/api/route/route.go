@@ -5,20 +5,43 @@ import (
 
 	"github.com/iemran93/devMatch/api/middleware"
 	"github.com/iemran93/devMatch/bootstrap"
+	"github.com/iemran93/devMatch/internal/metrics"
+	"github.com/iemran93/devMatch/repository"
 
 	"github.com/gorilla/mux"
 	"github.com/jmoiron/sqlx"
+	log "github.com/sirupsen/logrus"
 )
 
 func Setup(env *bootstrap.Env, timeout time.Duration, db *sqlx.DB, r *mux.Router) {
+	r.Use(middleware.RequestIDMiddleware)
+	r.Use(middleware.TracingMiddleware)
+	r.Use(middleware.MetricsMiddleware)
+
+	metrics.RegisterDB(db.DB, env.DBName)
+	// with METRICS_ADDR the metrics get their own listener, see main
+	if env.MetricsAddr == "" {
+		if env.MetricsToken != "" {
+			NewMetricsRouter(env, r)
+		} else {
+			log.Warn("metrics disabled: set METRICS_ADDR or METRICS_TOKEN to expose them")
+		}
+	}
+
 	public := r.PathPrefix("/api").Subrouter()
 	protectedRouter := r.PathPrefix("/api").Subrouter()
 
+	// Rate limiting, shared by both routers so a client has one bucket per group
+	rateLimiter := newRateLimiter(env)
+
 	// Middleware to verify AccessToken
 	// pass env to middleware
 	public.Use(middleware.LoggerMiddleware)
-	protectedRouter.Use(middleware.JwtAuthMiddleware(env.AccessTokenSecret))
+	public.Use(rateLimiter.Middleware)
+	// the logger runs first so requests rejected by the JWT check are logged
 	protectedRouter.Use(middleware.LoggerMiddleware)
+	protectedRouter.Use(middleware.JwtAuthMiddleware(env.AccessTokenSecret))
+	protectedRouter.Use(rateLimiter.Middleware)
 
 	// Register routes
 	NewGoogleRouter(env, timeout, db, public)
@@ -29,9 +52,43 @@ func Setup(env *bootstrap.Env, timeout time.Duration, db *sqlx.DB, r *mux.Router
 
 	NewUserRouter(env, timeout, db, protectedRouter)
 
-	NewProjectRouter(env, timeout, db, public, protectedRouter)
+	// One cached project repository is shared by every router that reads or
+	// changes projects, so writes anywhere invalidate the same cache
+	projectRepository := repository.NewCachedProjectRepository(
+		repository.NewProjectRepository(db),
+		time.Duration(env.CacheTaxonomyTTLSeconds)*time.Second,
+		env.CacheProjectSize,
+		time.Duration(env.CacheProjectTTLSeconds)*time.Second,
+	)
 
-	NewProjectActionsRouter(env, timeout, db, protectedRouter)
+	NewProjectRouter(env, timeout, projectRepository, public, protectedRouter)
+
+	NewProjectActionsRouter(env, timeout, db, projectRepository, protectedRouter)
+
+	NewProjectRolesRouter(env, timeout, db, projectRepository, protectedRouter)
+
+	NewTaxonomyRouter(env, timeout, db, projectRepository, protectedRouter)
+}
 
-	NewProjectRolesRouter(env, timeout, db, protectedRouter)
+func newRateLimiter(env *bootstrap.Env) *middleware.RateLimiter {
+	return middleware.NewRateLimiter(middleware.RateLimitPolicy{
+		Name:      "default",
+		PerMinute: env.RateLimitDefaultRPM,
+		Burst:     env.RateLimitDefaultBurst,
+	}, env.RateLimitTrustProxy).
+		Group(middleware.RateLimitPolicy{
+			Name:      "auth",
+			PerMinute: env.RateLimitAuthRPM,
+			Burst:     env.RateLimitAuthBurst,
+		}, "/api/login", "/api/signup", "/api/refresh_token", "/api/google").
+		Group(middleware.RateLimitPolicy{
+			Name:      "apply",
+			PerMinute: env.RateLimitApplyRPM,
+			Burst:     env.RateLimitApplyBurst,
+		}, "/api/project/request/apply").
+		Group(middleware.RateLimitPolicy{
+			Name:      "projects",
+			PerMinute: env.RateLimitProjectsRPM,
+			Burst:     env.RateLimitProjectsBurst,
+		}, "/api/projects")
 }
@@ -0,0 +1,41 @@
+package route
+
+import (
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/iemran93/devMatch/api/controller"
+	"github.com/iemran93/devMatch/api/middleware"
+	"github.com/iemran93/devMatch/bootstrap"
+	"github.com/iemran93/devMatch/repository"
+	"github.com/iemran93/devMatch/usecase"
+	"github.com/jmoiron/sqlx"
+)
+
+func NewTaxonomyRouter(env *bootstrap.Env, timeout time.Duration, db *sqlx.DB, pr repository.ProjectRepository, protectedRouter *mux.Router) {
+	tr := repository.NewTaxonomyRepository(db)
+	tc := &controller.TaxonomyController{
+		TaxonomyUseCase: usecase.NewTaxonomyUseCase(tr, pr, timeout),
+		Env:             env,
+	}
+	uu := usecase.NewUserUseCase(repository.NewUserRepository(db), timeout)
+
+	// any signed in user can propose a technology
+	protectedRouter.HandleFunc("/projects/technology/proposals", tc.ProposeTechnology).Methods("POST")
+
+	// admin routes
+	admin := protectedRouter.PathPrefix("/admin/taxonomy").Subrouter()
+	admin.Use(middleware.AdminMiddleware(uu))
+
+	admin.HandleFunc("/proposals", tc.ListProposals).Methods("GET")
+	admin.HandleFunc("/proposals/{id}/approve", tc.ApproveProposal).Methods("POST")
+	admin.HandleFunc("/proposals/{id}/reject", tc.RejectProposal).Methods("POST")
+
+	admin.HandleFunc("/{kind:category|technology|language|type}", tc.List).Methods("GET")
+	admin.HandleFunc("/{kind:category|technology|language|type}", tc.Create).Methods("POST")
+	admin.HandleFunc("/{kind:category|technology|language|type}/{id}", tc.Update).Methods("PUT")
+	admin.HandleFunc("/{kind:category|technology|language|type}/{id}/merge", tc.Merge).Methods("POST")
+	admin.HandleFunc("/{kind:category|technology|language|type}/{id}/aliases", tc.ListAliases).Methods("GET")
+	admin.HandleFunc("/{kind:category|technology|language|type}/{id}/aliases", tc.CreateAlias).Methods("POST")
+	admin.HandleFunc("/{kind:category|technology|language|type}/{id}/aliases/{aliasId}", tc.DeleteAlias).Methods("DELETE")
+}
@@ -1,24 +1,37 @@
 package bootstrap
 
 import (
+	"context"
+
 	"github.com/jmoiron/sqlx"
 	"github.com/rs/cors"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 )
 
 type Application struct {
-	Env   *Env
-	MySql *sqlx.DB
-	Cors  *cors.Cors
+	Env    *Env
+	MySql  *sqlx.DB
+	Cors   *cors.Cors
+	Tracer *sdktrace.TracerProvider
+	// Lifecycle is shared with the readiness probe
+	Lifecycle *Lifecycle
 }
 
 func App() Application {
 	app := &Application{}
 	app.Env = NewEnv()
+	NewLogger(app.Env)
+	app.Tracer = NewTracerProvider(app.Env)
 	app.MySql = NewMySQLDatabase(app.Env)
 	app.Cors = NewCorsHandler()
+	app.Lifecycle = NewLifecycle()
 	return *app
 }
 
 func (app *Application) CloseDBConnection() {
 	CloseMySqlConnection(app.MySql)
 }
+
+func (app *Application) CloseTracer(ctx context.Context) {
+	CloseTracerProvider(ctx, app.Tracer)
+}
@@ -23,6 +23,19 @@ func NewCorsHandler() *cors.Cors {
 			"X-CSRF-Token",
 			"X-Requested-With",
 			"Origin",
+			"X-Request-ID",
+			"If-Match",
+			"If-None-Match",
+		},
+		// Let the frontend read rate limit, caching and tracing information
+		ExposedHeaders: []string{
+			"RateLimit-Limit",
+			"RateLimit-Remaining",
+			"RateLimit-Reset",
+			"RateLimit-Policy",
+			"Retry-After",
+			"X-Request-ID",
+			"ETag",
 		},
 		// Allow credentials such as cookies to be sent with requests
 		AllowCredentials: true,
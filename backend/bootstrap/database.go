@@ -1,9 +1,11 @@
 package bootstrap
 
 import (
+	"github.com/XSAM/otelsql"
 	_ "github.com/go-sql-driver/mysql"
 	"github.com/jmoiron/sqlx"
 	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 func NewMySQLDatabase(env *Env) *sqlx.DB {
@@ -15,11 +17,20 @@ func NewMySQLDatabase(env *Env) *sqlx.DB {
 
 	// example connection string: "test:test@(localhost:3306)/test"
 
-	db, err := sqlx.Connect("mysql", dbUser+":"+dbPass+"@("+dbHost+":"+dbPort+")/"+dbName+"?parseTime=true")
+	// the driver is wrapped so every query is traced as a child of the request span
+	sqlDB, err := otelsql.Open("mysql", dbUser+":"+dbPass+"@("+dbHost+":"+dbPort+")/"+dbName+"?parseTime=true",
+		otelsql.WithAttributes(attribute.String("db.system", "mysql"), attribute.String("db.name", dbName)),
+		otelsql.WithSpanOptions(otelsql.SpanOptions{OmitConnResetSession: true, OmitRows: true}),
+	)
 	if err != nil {
 		log.Fatal(err)
 	}
 
+	db := sqlx.NewDb(sqlDB, "mysql")
+	if err := db.Ping(); err != nil {
+		log.Fatal(err)
+	}
+
 	return db
 }
 
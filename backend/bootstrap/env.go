@@ -22,11 +22,108 @@ type Env struct {
 	GoogleClientSecret     string `mapstructure:"GOOGLE_CLIENT_SECRET"`
 	MigrationPath          string `mapstructure:"MIGRATION_PATH"`
 	FrontendURL            string `mapstructure:"FRONTEND_URL"`
+	ShutdownDrainSeconds   int    `mapstructure:"SHUTDOWN_DRAIN_SECONDS"`
+
+	// Comma separated emails of the accounts made admins at startup. Admins
+	// manage the taxonomy; an account created later is granted on the next
+	// start. Removing an email does not revoke the flag.
+	AdminEmails string `mapstructure:"ADMIN_EMAILS"`
+
+	// Rate limits are expressed as requests per minute with a burst size.
+	RateLimitDefaultRPM    int `mapstructure:"RATE_LIMIT_DEFAULT_RPM"`
+	RateLimitDefaultBurst  int `mapstructure:"RATE_LIMIT_DEFAULT_BURST"`
+	RateLimitAuthRPM       int `mapstructure:"RATE_LIMIT_AUTH_RPM"`
+	RateLimitAuthBurst     int `mapstructure:"RATE_LIMIT_AUTH_BURST"`
+	RateLimitApplyRPM      int `mapstructure:"RATE_LIMIT_APPLY_RPM"`
+	RateLimitApplyBurst    int `mapstructure:"RATE_LIMIT_APPLY_BURST"`
+	RateLimitProjectsRPM   int `mapstructure:"RATE_LIMIT_PROJECTS_RPM"`
+	RateLimitProjectsBurst int `mapstructure:"RATE_LIMIT_PROJECTS_BURST"`
+	// Only enable behind a proxy that overwrites X-Forwarded-For.
+	RateLimitTrustProxy bool `mapstructure:"RATE_LIMIT_TRUST_PROXY"`
+
+	// Prometheus metrics. With METRICS_ADDR set, /metrics is served on its
+	// own listener, e.g. ":9090", kept off the public port. Otherwise it is
+	// served on SERVER_ADDRESS only when METRICS_TOKEN is set. When set, the
+	// token must be sent as "Authorization: Bearer <token>" on either
+	// listener.
+	MetricsAddr  string `mapstructure:"METRICS_ADDR"`
+	MetricsToken string `mapstructure:"METRICS_TOKEN"`
+
+	// Tracing exporter is one of "none", "stdout" or "otlp".
+	TracingExporter     string  `mapstructure:"TRACING_EXPORTER"`
+	TracingOTLPEndpoint string  `mapstructure:"TRACING_OTLP_ENDPOINT"`
+	TracingOTLPInsecure bool    `mapstructure:"TRACING_OTLP_INSECURE"`
+	TracingServiceName  string  `mapstructure:"TRACING_SERVICE_NAME"`
+	TracingSampleRatio  float64 `mapstructure:"TRACING_SAMPLE_RATIO"`
+
+	// In-process caches in front of the project repository. Any value of 0
+	// disables the cache it applies to.
+	CacheTaxonomyTTLSeconds int `mapstructure:"CACHE_TAXONOMY_TTL_SECONDS"`
+	CacheProjectSize        int `mapstructure:"CACHE_PROJECT_SIZE"`
+	CacheProjectTTLSeconds  int `mapstructure:"CACHE_PROJECT_TTL_SECONDS"`
+
+	// How long an owner's invitation to a role can be accepted.
+	InvitationTTLHours int `mapstructure:"INVITATION_TTL_HOURS"`
+
+	// Whether a reopened seat moves the first waitlisted requests to pending.
+	// When false the waiting users are only notified.
+	WaitlistAutoPromote bool `mapstructure:"WAITLIST_AUTO_PROMOTE"`
+
+	// Application limits. A user may have APPLY_MAX_PENDING_PER_USER and a
+	// role APPLY_MAX_PENDING_PER_ROLE pending or waitlisted applications,
+	// and a rejected user waits APPLY_REJECTION_COOLDOWN_HOURS before
+	// applying to the same role again. 0 disables a limit.
+	ApplyMaxPendingPerUser      int `mapstructure:"APPLY_MAX_PENDING_PER_USER"`
+	ApplyMaxPendingPerRole      int `mapstructure:"APPLY_MAX_PENDING_PER_ROLE"`
+	ApplyRejectionCooldownHours int `mapstructure:"APPLY_REJECTION_COOLDOWN_HOURS"`
+
+	// Background jobs. Pending applications expire after
+	// REQUEST_EXPIRY_DAYS and owners are reminded of applications older than
+	// REQUEST_REMINDER_HOURS, at most once per REQUEST_REMINDER_INTERVAL_HOURS.
+	// 0 disables expiry or reminders.
+	SchedulerEnabled             bool `mapstructure:"SCHEDULER_ENABLED"`
+	RequestExpiryDays            int  `mapstructure:"REQUEST_EXPIRY_DAYS"`
+	RequestReminderHours         int  `mapstructure:"REQUEST_REMINDER_HOURS"`
+	RequestReminderIntervalHours int  `mapstructure:"REQUEST_REMINDER_INTERVAL_HOURS"`
+}
+
+func setDefaults() {
+	viper.SetDefault("SHUTDOWN_DRAIN_SECONDS", 5)
+	viper.SetDefault("ADMIN_EMAILS", "")
+	viper.SetDefault("RATE_LIMIT_DEFAULT_RPM", 300)
+	viper.SetDefault("RATE_LIMIT_DEFAULT_BURST", 60)
+	viper.SetDefault("RATE_LIMIT_AUTH_RPM", 10)
+	viper.SetDefault("RATE_LIMIT_AUTH_BURST", 5)
+	viper.SetDefault("RATE_LIMIT_APPLY_RPM", 20)
+	viper.SetDefault("RATE_LIMIT_APPLY_BURST", 5)
+	viper.SetDefault("RATE_LIMIT_PROJECTS_RPM", 120)
+	viper.SetDefault("RATE_LIMIT_PROJECTS_BURST", 30)
+	viper.SetDefault("RATE_LIMIT_TRUST_PROXY", false)
+	viper.SetDefault("METRICS_ADDR", "")
+	viper.SetDefault("METRICS_TOKEN", "")
+	viper.SetDefault("TRACING_EXPORTER", "none")
+	viper.SetDefault("TRACING_OTLP_ENDPOINT", "")
+	viper.SetDefault("TRACING_OTLP_INSECURE", false)
+	viper.SetDefault("TRACING_SERVICE_NAME", "devmatch-backend")
+	viper.SetDefault("TRACING_SAMPLE_RATIO", 1.0)
+	viper.SetDefault("CACHE_TAXONOMY_TTL_SECONDS", 600)
+	viper.SetDefault("CACHE_PROJECT_SIZE", 1000)
+	viper.SetDefault("CACHE_PROJECT_TTL_SECONDS", 60)
+	viper.SetDefault("INVITATION_TTL_HOURS", 168)
+	viper.SetDefault("WAITLIST_AUTO_PROMOTE", false)
+	viper.SetDefault("APPLY_MAX_PENDING_PER_USER", 10)
+	viper.SetDefault("APPLY_MAX_PENDING_PER_ROLE", 50)
+	viper.SetDefault("APPLY_REJECTION_COOLDOWN_HOURS", 72)
+	viper.SetDefault("SCHEDULER_ENABLED", true)
+	viper.SetDefault("REQUEST_EXPIRY_DAYS", 30)
+	viper.SetDefault("REQUEST_REMINDER_HOURS", 48)
+	viper.SetDefault("REQUEST_REMINDER_INTERVAL_HOURS", 24)
 }
 
 func NewEnv() *Env {
 	env := Env{}
 	viper.SetConfigFile(".env")
+	setDefaults()
 
 	err := viper.ReadInConfig()
 	if err != nil {
@@ -0,0 +1,21 @@
+package bootstrap
+
+import "sync/atomic"
+
+// Lifecycle tracks whether the server is shutting down, so readiness can
+// fail and load balancers drain traffic before connections are closed.
+type Lifecycle struct {
+	draining atomic.Bool
+}
+
+func NewLifecycle() *Lifecycle {
+	return &Lifecycle{}
+}
+
+func (l *Lifecycle) StartDraining() {
+	l.draining.Store(true)
+}
+
+func (l *Lifecycle) IsDraining() bool {
+	return l.draining.Load()
+}
@@ -0,0 +1,40 @@
+package bootstrap
+
+import (
+	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// NewLogger configures the global logrus logger. Outside development logs are
+// emitted as JSON so the request fields can be indexed.
+func NewLogger(env *Env) {
+	if env.AppEnv != "development" {
+		log.SetFormatter(&log.JSONFormatter{})
+	}
+	log.AddHook(contextHook{})
+}
+
+// contextHook copies request scoped values into entries logged with
+// log.WithContext(ctx), so errors can be correlated with their request.
+type contextHook struct{}
+
+func (contextHook) Levels() []log.Level {
+	return log.AllLevels
+}
+
+func (contextHook) Fire(entry *log.Entry) error {
+	if entry.Context == nil {
+		return nil
+	}
+	if requestID, ok := entry.Context.Value("request_id").(string); ok {
+		entry.Data["request_id"] = requestID
+	}
+	if userID, ok := entry.Context.Value("user_id").(int); ok {
+		entry.Data["user_id"] = userID
+	}
+	if sc := trace.SpanContextFromContext(entry.Context); sc.IsValid() {
+		entry.Data["trace_id"] = sc.TraceID().String()
+		entry.Data["span_id"] = sc.SpanID().String()
+	}
+	return nil
+}
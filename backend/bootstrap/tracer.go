@@ -0,0 +1,76 @@
+package bootstrap
+
+import (
+	"context"
+	"os"
+
+	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// NewTracerProvider installs the global tracer provider configured by
+// TRACING_EXPORTER: "otlp", "stdout" or "none" (default). With "none" the
+// global no-op provider is left in place and nil is returned.
+func NewTracerProvider(env *Env) *sdktrace.TracerProvider {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{}, propagation.Baggage{},
+	))
+
+	var exporter sdktrace.SpanExporter
+	var err error
+	switch env.TracingExporter {
+	case "otlp":
+		opts := []otlptracehttp.Option{}
+		if env.TracingOTLPEndpoint != "" {
+			opts = append(opts, otlptracehttp.WithEndpoint(env.TracingOTLPEndpoint))
+		}
+		if env.TracingOTLPInsecure {
+			opts = append(opts, otlptracehttp.WithInsecure())
+		}
+		exporter, err = otlptracehttp.New(context.Background(), opts...)
+	case "stdout":
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(os.Stdout), stdouttrace.WithPrettyPrint())
+	case "", "none":
+		return nil
+	default:
+		log.Warn("unknown tracing exporter ", env.TracingExporter, ", tracing disabled")
+		return nil
+	}
+	if err != nil {
+		log.Fatal("Tracing exporter can't be created: ", err)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(
+		attribute.String("service.name", env.TracingServiceName),
+		attribute.String("deployment.environment", env.AppEnv),
+	))
+	if err != nil {
+		log.Fatal("Tracing resource can't be created: ", err)
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(env.TracingSampleRatio))),
+	)
+	otel.SetTracerProvider(tp)
+
+	return tp
+}
+
+// CloseTracerProvider flushes pending spans.
+func CloseTracerProvider(ctx context.Context, tp *sdktrace.TracerProvider) {
+	if tp == nil {
+		return
+	}
+
+	if err := tp.Shutdown(ctx); err != nil {
+		log.Error("error while shutting down tracer provider: ", err)
+	}
+}
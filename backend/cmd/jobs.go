@@ -0,0 +1,31 @@
+package main
+
+import (
+	"time"
+
+	"github.com/iemran93/devMatch/bootstrap"
+	"github.com/iemran93/devMatch/internal/scheduler"
+	"github.com/iemran93/devMatch/repository"
+	"github.com/iemran93/devMatch/usecase"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// newScheduler registers the background jobs. Every replica runs them;
+// leases in the database keep each run to one replica.
+func newScheduler(env *bootstrap.Env, db *sqlx.DB) *scheduler.Scheduler {
+	jobs := usecase.NewRequestJobs(
+		repository.NewProjectActionsRepository(db),
+		repository.NewNotificationRepository(db),
+		usecase.RequestJobsConfig{
+			ExpireAfter: time.Duration(env.RequestExpiryDays) * 24 * time.Hour,
+			RemindAfter: time.Duration(env.RequestReminderHours) * time.Hour,
+			RemindEvery: time.Duration(env.RequestReminderIntervalHours) * time.Hour,
+		},
+	)
+
+	s := scheduler.New(repository.NewLeaseRepository(db), 30*time.Second)
+	s.Add(scheduler.Job{Name: "expire_requests", Interval: 15 * time.Minute, Run: jobs.ExpireRequests})
+	s.Add(scheduler.Job{Name: "remind_owners", Interval: time.Hour, Run: jobs.RemindOwners})
+	return s
+}
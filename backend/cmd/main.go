@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"syscall"
 	"time"
 
 	"github.com/iemran93/devMatch/api/route"
@@ -22,12 +23,14 @@ func main() {
 	defer app.CloseDBConnection()
 
 	utils.MigrateDB(db, env)
+	utils.GrantAdmins(db, env)
 
 	timeout := time.Duration(env.ContextTimeout) * time.Second
 
 	r := mux.NewRouter()
 
 	route.Setup(env, timeout, db, r)
+	route.NewHealthRouter(env, timeout, db, app.Lifecycle, r)
 
 	// Create CORS handler
 	corsHandler := bootstrap.NewCorsHandler()
@@ -48,16 +51,50 @@ func main() {
 
 	log.Info("server started")
 
+	// metrics are scraped on their own address, off the public listener
+	var metricsSrv *http.Server
+	if env.MetricsAddr != "" {
+		metricsRouter := mux.NewRouter()
+		route.NewMetricsRouter(env, metricsRouter)
+		metricsSrv = &http.Server{
+			Addr:         env.MetricsAddr,
+			WriteTimeout: time.Second * 15,
+			ReadTimeout:  time.Second * 15,
+			IdleTimeout:  time.Second * 60,
+			Handler:      metricsRouter,
+		}
+		go func() {
+			if err := metricsSrv.ListenAndServe(); err != nil {
+				log.Error(err)
+			}
+		}()
+	}
+
+	jobs := newScheduler(env, db)
+	if env.SchedulerEnabled {
+		jobs.Start()
+	}
+
 	// Graceful Shutdown
 	c := make(chan os.Signal, 1)
-	signal.Notify(c, os.Interrupt)
+	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
 	// Block until we receive our signal.
 	<-c
 
+	// Fail readiness first and give load balancers time to stop routing to us.
+	app.Lifecycle.StartDraining()
+	log.Info("draining connections")
+	time.Sleep(time.Duration(env.ShutdownDrainSeconds) * time.Second)
+
 	// Create a deadline to wait for.
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
 	srv.Shutdown(ctx)
+	if metricsSrv != nil {
+		metricsSrv.Shutdown(ctx)
+	}
+	jobs.Stop()
+	app.CloseTracer(ctx)
 	log.Info("shutting down")
 	os.Exit(0)
 }
@@ -0,0 +1,195 @@
+package domain
+
+import (
+	"slices"
+	"sort"
+	"time"
+)
+
+// Applicant sort keys
+const (
+	ApplicantSortCreated = "created_at"
+	ApplicantSortMatch   = "match_score"
+	ApplicantSortRating  = "rating"
+)
+
+// Applicant is the owner's view of a request: the request with the
+// applicant's profile and skills, the owner's private review and how well
+// the skills match the project.
+type Applicant struct {
+	*ProjectRequest
+	// Profile is nil for invitations to an email without an account
+	Profile    *ApplicantProfile `json:"applicant"`
+	Skills     []ApplicantSkill  `json:"skills"`
+	Review     ApplicantReview   `json:"review"`
+	MatchScore int               `json:"match_score"`
+}
+
+type ApplicantProfile struct {
+	Id             int     `json:"id" db:"id"`
+	Name           string  `json:"name" db:"name"`
+	Email          string  `json:"email" db:"email"`
+	ProfilePicture *string `json:"profile_picture,omitempty" db:"profile_picture"`
+	Availability   bool    `json:"availability" db:"availability"`
+}
+
+// ApplicantSkill is one UserSkill row with the technology or language it
+// names.
+type ApplicantSkill struct {
+	UserId           int     `json:"-" db:"user_id"`
+	CategoryId       *int    `json:"category_id,omitempty" db:"category_id"`
+	TechnologyId     *int    `json:"technology_id,omitempty" db:"technology_id"`
+	Technology       *string `json:"technology,omitempty" db:"technology"`
+	LanguageId       *int    `json:"language_id,omitempty" db:"language_id"`
+	Language         *string `json:"language,omitempty" db:"language"`
+	ProficiencyLevel *string `json:"proficiency_level,omitempty" db:"proficiency_level"`
+}
+
+// ApplicantReview holds the owner's private notes on a request. Requests
+// that were never reviewed have the zero value.
+type ApplicantReview struct {
+	RequestId   int        `json:"-" db:"request_id"`
+	Shortlisted bool       `json:"shortlisted" db:"shortlisted"`
+	Tags        StringList `json:"tags" db:"tags"`
+	Rating      *int       `json:"rating,omitempty" db:"rating"`
+	Note        *string    `json:"note,omitempty" db:"note"`
+	UpdatedAt   *time.Time `json:"updated_at,omitempty" db:"updated_at"`
+}
+
+// ReviewApplicantRequest changes the fields it sets. A rating of 0 clears
+// the rating.
+type ReviewApplicantRequest struct {
+	Shortlisted *bool     `json:"shortlisted"`
+	Tags        *[]string `json:"tags" validate:"omitnil,max=10,unique,dive,required,max=50"`
+	Rating      *int      `json:"rating" validate:"omitnil,min=0,max=5"`
+	Note        *string   `json:"note" validate:"omitnil,max=2000"`
+}
+
+// ApplicantFilter narrows and orders the applicants of a project. Zero
+// values do not filter.
+type ApplicantFilter struct {
+	RoleId      int    `json:"role_id" validate:"min=0"`
+	Status      string `json:"status" validate:"omitempty,oneof=pending accepted rejected cancelled withdrawn removed expired waitlisted"`
+	Shortlisted *bool  `json:"shortlisted"`
+	Tag         string `json:"tag" validate:"max=50"`
+	MinRating   int    `json:"min_rating" validate:"min=0,max=5"`
+	MinScore    int    `json:"min_score" validate:"min=0,max=100"`
+	Sort        string `json:"sort" validate:"omitempty,oneof=created_at match_score rating"`
+	Order       string `json:"order" validate:"omitempty,oneof=asc desc"`
+}
+
+func (rr *ReviewApplicantRequest) Validate() error {
+	return validateStruct(rr)
+}
+
+func (f *ApplicantFilter) Validate() error {
+	return validateStruct(f)
+}
+
+// Apply copies the fields set in req onto the review.
+func (r *ApplicantReview) Apply(req *ReviewApplicantRequest) {
+	if req.Shortlisted != nil {
+		r.Shortlisted = *req.Shortlisted
+	}
+	if req.Tags != nil {
+		r.Tags = StringList(*req.Tags)
+	}
+	if req.Rating != nil {
+		r.Rating = req.Rating
+		if *req.Rating == 0 {
+			r.Rating = nil
+		}
+	}
+	if req.Note != nil {
+		r.Note = req.Note
+		if *req.Note == "" {
+			r.Note = nil
+		}
+	}
+}
+
+// MatchScore is the percentage of the given technologies and languages, the
+// role's or else the project's, the applicant lists among their skills.
+// Nothing to match scores 0.
+func MatchScore(technologies []int, languages []int, skills []ApplicantSkill) int {
+	total := len(technologies) + len(languages)
+	if total == 0 {
+		return 0
+	}
+
+	var hasTechnology, hasLanguage []int
+	for _, s := range skills {
+		if s.TechnologyId != nil {
+			hasTechnology = append(hasTechnology, *s.TechnologyId)
+		}
+		if s.LanguageId != nil {
+			hasLanguage = append(hasLanguage, *s.LanguageId)
+		}
+	}
+
+	matched := 0
+	for _, id := range technologies {
+		if slices.Contains(hasTechnology, id) {
+			matched++
+		}
+	}
+	for _, id := range languages {
+		if slices.Contains(hasLanguage, id) {
+			matched++
+		}
+	}
+	return matched * 100 / total
+}
+
+// FilterApplicants returns the applicants matching f in the order it asks
+// for, newest first by default. Unrated applicants rate as 0.
+func FilterApplicants(applicants []*Applicant, f ApplicantFilter) []*Applicant {
+	result := make([]*Applicant, 0, len(applicants))
+	for _, a := range applicants {
+		if f.RoleId != 0 && a.RoleId != f.RoleId {
+			continue
+		}
+		if f.Status != "" && a.Status != f.Status {
+			continue
+		}
+		if f.Shortlisted != nil && a.Review.Shortlisted != *f.Shortlisted {
+			continue
+		}
+		if f.Tag != "" && !slices.Contains(a.Review.Tags, f.Tag) {
+			continue
+		}
+		if f.MinRating > 0 && (a.Review.Rating == nil || *a.Review.Rating < f.MinRating) {
+			continue
+		}
+		if a.MatchScore < f.MinScore {
+			continue
+		}
+		result = append(result, a)
+	}
+
+	rating := func(a *Applicant) int {
+		if a.Review.Rating == nil {
+			return 0
+		}
+		return *a.Review.Rating
+	}
+	less := func(i, j int) bool {
+		a, b := result[i], result[j]
+		switch f.Sort {
+		case ApplicantSortMatch:
+			return a.MatchScore < b.MatchScore
+		case ApplicantSortRating:
+			return rating(a) < rating(b)
+		default:
+			// ids grow with creation time and have no ties
+			return a.Id < b.Id
+		}
+	}
+
+	if f.Order == "asc" {
+		sort.SliceStable(result, less)
+	} else {
+		sort.SliceStable(result, func(i, j int) bool { return less(j, i) })
+	}
+	return result
+}
@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchScore(t *testing.T) {
+	golang, react, english := 1, 2, 7
+	skills := []ApplicantSkill{
+		{TechnologyId: &golang},
+		{LanguageId: &english},
+		// a language id must not count as a technology
+		{LanguageId: &react},
+	}
+
+	assert.Equal(t, 66, MatchScore([]int{golang, react}, []int{english}, skills))
+	assert.Equal(t, 0, MatchScore([]int{react}, nil, skills))
+	assert.Equal(t, 0, MatchScore(nil, nil, skills))
+}
+
+func TestFilterApplicants(t *testing.T) {
+	four, two := 4, 2
+	applicants := []*Applicant{
+		{ProjectRequest: &ProjectRequest{Id: 1, RoleId: 1, Status: RequestPending}, MatchScore: 50,
+			Review: ApplicantReview{Shortlisted: true, Tags: StringList{"backend"}, Rating: &two}},
+		{ProjectRequest: &ProjectRequest{Id: 2, RoleId: 1, Status: RequestPending}, MatchScore: 100},
+		{ProjectRequest: &ProjectRequest{Id: 3, RoleId: 2, Status: RequestRejected}, MatchScore: 0},
+		{ProjectRequest: &ProjectRequest{Id: 4, RoleId: 1, Status: RequestPending}, MatchScore: 75,
+			Review: ApplicantReview{Shortlisted: true, Rating: &four}},
+	}
+
+	ids := func(result []*Applicant) []int {
+		var ids []int
+		for _, a := range result {
+			ids = append(ids, a.Id)
+		}
+		return ids
+	}
+
+	assert.Equal(t, []int{4, 3, 2, 1}, ids(FilterApplicants(applicants, ApplicantFilter{})))
+	assert.Equal(t, []int{2, 4, 1}, ids(FilterApplicants(applicants, ApplicantFilter{RoleId: 1, Sort: ApplicantSortMatch})))
+	assert.Equal(t, []int{3, 1, 4, 2}, ids(FilterApplicants(applicants, ApplicantFilter{Sort: ApplicantSortMatch, Order: "asc"})))
+
+	shortlisted := true
+	assert.Equal(t, []int{4, 1}, ids(FilterApplicants(applicants, ApplicantFilter{Shortlisted: &shortlisted, Sort: ApplicantSortRating})))
+	assert.Equal(t, []int{1}, ids(FilterApplicants(applicants, ApplicantFilter{Tag: "backend"})))
+	assert.Equal(t, []int{4}, ids(FilterApplicants(applicants, ApplicantFilter{MinRating: 3})))
+	assert.Equal(t, []int{4, 2}, ids(FilterApplicants(applicants, ApplicantFilter{Status: RequestPending, MinScore: 60})))
+}
+
+func TestReviewApplicantRequest(t *testing.T) {
+	six := 6
+	tags := []string{"backend", "backend"}
+	req := &ReviewApplicantRequest{Rating: &six, Tags: &tags}
+
+	var ve *ValidationError
+	assert.True(t, errors.As(req.Validate(), &ve))
+	assert.ElementsMatch(t, []FieldError{
+		{Field: "tags", Rule: "unique", Message: "must not contain duplicates"},
+		{Field: "rating", Rule: "max", Message: "must be at most 5"},
+	}, ve.Errors)
+
+	// 0 clears the rating, an empty note clears the note
+	three, zero, note, empty := 3, 0, "strong portfolio", ""
+	review := ApplicantReview{}
+	review.Apply(&ReviewApplicantRequest{Rating: &three, Note: &note})
+	assert.Equal(t, 3, *review.Rating)
+	assert.Equal(t, "strong portfolio", *review.Note)
+
+	review.Apply(&ReviewApplicantRequest{Rating: &zero, Note: &empty})
+	assert.Nil(t, review.Rating)
+	assert.Nil(t, review.Note)
+}
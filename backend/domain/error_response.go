@@ -1,29 +1,83 @@
 package domain
 
-import "errors"
+import "net/http"
 
-type ErrorResponse struct {
-	Message string `json:"message"`
+// ProblemDetails is the RFC 7807 body written for every error response.
+type ProblemDetails struct {
+	Type      string `json:"type"`
+	Title     string `json:"title"`
+	Status    int    `json:"status"`
+	Detail    string `json:"detail,omitempty"`
+	Instance  string `json:"instance,omitempty"`
+	Code      string `json:"code"`
+	RequestId string `json:"request_id,omitempty"`
+
+	// Errors lists the failed fields of a ValidationError.
+	Errors []FieldError `json:"errors,omitempty"`
+}
+
+// Error is a domain error with a stable machine readable code, the HTTP
+// status it maps to and a message that is safe to show to clients.
+type Error struct {
+	Code    string
+	Status  int
+	Message string
+}
+
+func NewError(code string, status int, message string) *Error {
+	return &Error{Code: code, Status: status, Message: message}
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Is matches errors by code, so errors.Is works for copies made with
+// WithMessage.
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Code == e.Code
+}
+
+// WithMessage returns a copy of the error with a more specific message.
+func (e *Error) WithMessage(message string) *Error {
+	return &Error{Code: e.Code, Status: e.Status, Message: message}
 }
 
 // Error List:
 var (
-	ErrUserAlreadyExists          = errors.New("user already exists")
-	ErrUserNotAllowed             = errors.New("user not allowed")
-	ErrUserNotFound               = errors.New("user not found")
-	ErrUnauthorized               = errors.New("unauthorized")
-	ErrInvalidPassword            = errors.New("invalid password")
-	ErrUserShouldLoginWithGoogle  = errors.New("user should login with Google")
-	ErrCodeExchangeWrong          = errors.New("code exchange wrong")
-	ErrFailedGetGoogleUser        = errors.New("failed to get google user")
-	ErrFailedToReadResponse       = errors.New("failed to read response")
-	ErrUnexpectedSigningMethod    = errors.New("unexpected signing method")
-	ErrInvalidToken               = errors.New("invalid token")
-	ErrIncorrectRequestBody       = errors.New("incorrect request body")
-	ErrProjectNotFound            = errors.New("project not found")
-	ErrRequestAlreadyExists       = errors.New("request already exists")
-	ErrRequestNotFound            = errors.New("request not found")
-	ErrFaildToChangeRequestStatus = errors.New("failed to change request status")
-	ErrRequestNorAllowed          = errors.New("Request not allowed")
-	ErrInternalServerError        = errors.New("Internal server error")
+	ErrUserAlreadyExists          = NewError("user_already_exists", http.StatusConflict, "user already exists")
+	ErrUserNotAllowed             = NewError("user_not_allowed", http.StatusForbidden, "user not allowed")
+	ErrUserNotFound               = NewError("user_not_found", http.StatusNotFound, "user not found")
+	ErrUnauthorized               = NewError("unauthorized", http.StatusUnauthorized, "unauthorized")
+	ErrInvalidCredentials         = NewError("invalid_credentials", http.StatusUnauthorized, "invalid email or password")
+	ErrUserShouldLoginWithGoogle  = NewError("google_login_required", http.StatusBadRequest, "user should login with Google")
+	ErrCodeExchangeWrong          = NewError("code_exchange_failed", http.StatusBadGateway, "code exchange wrong")
+	ErrFailedGetGoogleUser        = NewError("google_user_unavailable", http.StatusBadGateway, "failed to get google user")
+	ErrFailedToReadResponse       = NewError("google_response_unreadable", http.StatusBadGateway, "failed to read response")
+	ErrUnexpectedSigningMethod    = NewError("unexpected_signing_method", http.StatusUnauthorized, "unexpected signing method")
+	ErrInvalidToken               = NewError("invalid_token", http.StatusUnauthorized, "invalid token")
+	ErrIncorrectRequestBody       = NewError("incorrect_request_body", http.StatusBadRequest, "incorrect request body")
+	ErrValidationFailed           = NewError("validation_failed", http.StatusUnprocessableEntity, "request validation failed")
+	ErrInvalidId                  = NewError("invalid_id", http.StatusBadRequest, "invalid id")
+	ErrProjectNotFound            = NewError("project_not_found", http.StatusNotFound, "project not found")
+	ErrRoleNotFound               = NewError("role_not_found", http.StatusNotFound, "role not found")
+	ErrQuestionNotFound           = NewError("question_not_found", http.StatusNotFound, "question not found")
+	ErrRequestAlreadyExists       = NewError("request_already_exists", http.StatusConflict, "request already exists")
+	ErrRequestNotFound            = NewError("request_not_found", http.StatusNotFound, "request not found")
+	ErrFaildToChangeRequestStatus = NewError("request_status_change_failed", http.StatusInternalServerError, "failed to change request status")
+	ErrRequestNorAllowed          = NewError("request_not_allowed", http.StatusConflict, "Request not allowed")
+	ErrInvalidTransition          = NewError("invalid_request_transition", http.StatusConflict, "request cannot change to that status")
+	ErrInvitationExpired          = NewError("invitation_expired", http.StatusGone, "invitation has expired")
+	ErrApplicationLimit           = NewError("application_limit_reached", http.StatusConflict, "too many open applications")
+	ErrRoleApplicationLimit       = NewError("role_application_limit_reached", http.StatusConflict, "the role has too many open applications")
+	ErrApplyCooldown              = NewError("apply_cooldown", http.StatusConflict, "rejected for this role recently, try again later")
+	ErrTaxonomyNotFound           = NewError("taxonomy_not_found", http.StatusNotFound, "taxonomy entry not found")
+	ErrTaxonomyAlreadyExists      = NewError("taxonomy_already_exists", http.StatusConflict, "taxonomy entry already exists")
+	ErrProposalNotFound           = NewError("proposal_not_found", http.StatusNotFound, "proposal not found")
+	ErrProposalAlreadyReviewed    = NewError("proposal_already_reviewed", http.StatusConflict, "proposal already reviewed")
+	ErrPreconditionFailed         = NewError("precondition_failed", http.StatusPreconditionFailed, "resource was modified since it was fetched")
+	ErrVersionConflict            = NewError("version_conflict", http.StatusConflict, "resource was modified by someone else, reload and try again")
+	ErrInternalServerError        = NewError("internal_server_error", http.StatusInternalServerError, "Internal server error")
+	ErrTooManyRequests            = NewError("too_many_requests", http.StatusTooManyRequests, "too many requests")
 )
@@ -3,13 +3,12 @@ package domain
 import (
 	"context"
 
-	"github.com/go-playground/validator/v10"
 	"github.com/iemran93/devMatch/bootstrap"
 )
 
 type LoginRequest struct {
-	Email    string `form:"email" binding:"required,email"`
-	Password string `form:"password" binding:"required"`
+	Email    string `json:"email" validate:"required,email"`
+	Password string `json:"password" validate:"required"`
 }
 
 type LoginResponse struct {
@@ -22,12 +21,7 @@ type LoginResponse struct {
 }
 
 func (lr *LoginRequest) Validate() error {
-	v := validator.New()
-	err := v.Struct(lr)
-	if err != nil {
-		return err
-	}
-	return nil
+	return validateStruct(lr)
 }
 
 type LoginUseCase interface {
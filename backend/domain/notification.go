@@ -0,0 +1,28 @@
+package domain
+
+import "time"
+
+// Notification types
+const (
+	NotificationRequestReminder  = "request_reminder"
+	NotificationRequestExpired   = "request_expired"
+	NotificationMemberRemoved    = "member_removed"
+	NotificationSeatReopened     = "seat_reopened"
+	NotificationWaitlistPromoted = "waitlist_promoted"
+
+	NotificationInvitationReceived  = "invitation_received"
+	NotificationInvitationAccepted  = "invitation_accepted"
+	NotificationInvitationDeclined  = "invitation_declined"
+	NotificationInvitationCancelled = "invitation_cancelled"
+)
+
+type Notification struct {
+	Id        int       `json:"id" db:"id"`
+	UserId    int       `json:"user_id" db:"user_id"`
+	ActorId   *int      `json:"actor_id,omitempty" db:"actor_id"`
+	ProjectId *int      `json:"project_id,omitempty" db:"project_id"`
+	Type      string    `json:"type" db:"type"`
+	Content   string    `json:"content" db:"content"`
+	IsRead    bool      `json:"is_read" db:"is_read"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
@@ -2,9 +2,8 @@ package domain
 
 import (
 	"context"
+	"slices"
 	"time"
-
-	"github.com/go-playground/validator/v10"
 )
 
 type Category struct {
@@ -27,21 +26,72 @@ type Language struct {
 	Name string `json:"name"`
 }
 
+// Role experience levels
+const (
+	ExperienceJunior = "junior"
+	ExperienceMid    = "mid"
+	ExperienceSenior = "senior"
+)
+
 type ProjectRole struct {
-	Id                     int    `json:"id" db:"id"`
-	ProjectId              int    `json:"project_id" db:"project_id"`
-	Title                  string `json:"title" db:"title"`
-	Description            string `json:"description" db:"description"`
-	RequiredExperienceLeve int    `json:"required_experience_level" db:"required_experience_level"`
-	IsFilled               bool   `json:"is_filled" db:"is_filled"`
+	Id              int    `json:"id" db:"id"`
+	ProjectId       int    `json:"project_id" db:"project_id"`
+	Title           string `json:"title" db:"title"`
+	Description     string `json:"description" db:"description"`
+	ExperienceLevel string `json:"experience_level" db:"experience_level"`
+	// IsFilled is true once every seat is filled
+	IsFilled       bool `json:"is_filled" db:"is_filled"`
+	Seats          int  `json:"seats" db:"seats"`
+	FilledSeats    int  `json:"filled_seats" db:"filled_seats"`
+	SeatsRemaining int  `json:"seats_remaining" db:"seats_remaining"`
+	// Version counts owner edits only; seats taken or freed by members do
+	// not bump it
+	Version int `json:"version" db:"version"`
+	// Technologies and Languages are what the role asks for, loaded
+	// separately from the role row
+	Technologies []RoleSkill `json:"technologies" db:"-"`
+	Languages    []RoleSkill `json:"languages" db:"-"`
+}
+
+// RoleSkill is a technology or language a role asks for. Skills that are
+// not required are nice to have.
+type RoleSkill struct {
+	Id       int    `json:"id" db:"id"`
+	Name     string `json:"name" db:"name"`
+	Required bool   `json:"required" db:"required"`
+}
+
+// SkillIds returns the ids of the role's technologies and languages.
+func (r *ProjectRole) SkillIds() (technologies []int, languages []int) {
+	for _, t := range r.Technologies {
+		technologies = append(technologies, t.Id)
+	}
+	for _, l := range r.Languages {
+		languages = append(languages, l.Id)
+	}
+	return technologies, languages
+}
+
+type RoleSkillRequest struct {
+	Id       int  `json:"id" validate:"taxonomy_id"`
+	Required bool `json:"required"`
 }
 
 type ProjectRoleRequest struct {
-	Title                  string `json:"title" validate:"required" db:"title"`
-	ProjectId              int    `json:"project_id" db:"project_id"`
-	Description            string `json:"description" db:"description"`
-	RequiredExperienceLeve int    `json:"required_experience_level" db:"required_experience_level"`
-	IsFilled               bool   `json:"is_filled" db:"if_filled"`
+	Title       string `json:"title" validate:"required" db:"title"`
+	ProjectId   int    `json:"project_id" db:"project_id"`
+	Description string `json:"description" db:"description"`
+	// ExperienceLevel is junior, mid or senior. Empty means mid when creating
+	// and keeps the current level when updating.
+	ExperienceLevel string `json:"experience_level" validate:"omitempty,oneof=junior mid senior"`
+	// Seats is how many members the role takes. 0 means 1 when creating and
+	// keeps the current count when updating.
+	Seats int `json:"seats" validate:"min=0,max=100"`
+	// Technologies and Languages replace the role's skills; nil keeps them.
+	Technologies []RoleSkillRequest `json:"technologies" validate:"max=20,unique=Id,dive"`
+	Languages    []RoleSkillRequest `json:"languages" validate:"max=20,unique=Id,dive"`
+	// Version is the version the client last read; only updates use it.
+	Version int `json:"version,omitempty" validate:"gte=0"`
 }
 
 type Project struct {
@@ -54,28 +104,51 @@ type Project struct {
 	CreatedAt   time.Time `json:"created_at" db:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`
 	CreatorId   int       `json:"creator_id" db:"creator_id"`
+	Version     int       `json:"version" db:"version"`
 }
 
 type UpdateProjectRequest struct {
+	Version      int     `json:"version" validate:"required,min=1"`
 	Title        string  `json:"title" validate:"required"`
 	Description  string  `json:"description" validate:"required"`
 	Goals        *string `json:"goals"`
-	CategoryId   int     `json:"category_id" validate:"required"`
-	Stage        string  `json:"stage" validate:"required,oneof=Idea 'In Progress' Completed"`
-	ProjectType  []int   `json:"project_type" validate:"required,min=1"`
-	Technologies []int   `json:"technologies" validate:"required,min=1"`
-	Languages    []int   `json:"languages" validate:"required,min=1"`
+	CategoryId   int     `json:"category_id" validate:"required,taxonomy_id"`
+	Stage        string  `json:"stage" validate:"required,stage"`
+	ProjectType  []int   `json:"project_type" validate:"required,min=1,unique,dive,taxonomy_id"`
+	Technologies []int   `json:"technologies" validate:"required,min=1,unique,dive,taxonomy_id"`
+	Languages    []int   `json:"languages" validate:"required,min=1,unique,dive,taxonomy_id"`
+}
+
+// LinkPatch adds and removes ids of one link collection, leaving the others
+// untouched.
+type LinkPatch struct {
+	Add    []int `json:"add" validate:"unique,dive,taxonomy_id"`
+	Remove []int `json:"remove" validate:"unique,dive,taxonomy_id"`
+}
+
+// PatchProjectRequest is a partial update: nil fields are left as they are.
+// An empty goals string clears the goals.
+type PatchProjectRequest struct {
+	Version      int        `json:"version" validate:"required,min=1"`
+	Title        *string    `json:"title" validate:"omitnil,min=1"`
+	Description  *string    `json:"description" validate:"omitnil,min=1"`
+	Goals        *string    `json:"goals"`
+	CategoryId   *int       `json:"category_id" validate:"omitnil,taxonomy_id"`
+	Stage        *string    `json:"stage" validate:"omitnil,stage"`
+	ProjectType  *LinkPatch `json:"project_type"`
+	Technologies *LinkPatch `json:"technologies"`
+	Languages    *LinkPatch `json:"languages"`
 }
 
 type CreateProjectRequest struct {
 	Title        string               `json:"title" validate:"required"`
 	Description  string               `json:"description" validate:"required"`
 	Goals        *string              `json:"goals"`
-	CategoryId   int                  `json:"category_id" validate:"required"`
-	Stage        string               `json:"stage" validate:"required,oneof=Idea 'In Progress' Completed"`
-	ProjectType  []int                `json:"project_type" validate:"required,min=1"`
-	Technologies []int                `json:"technologies" validate:"required,min=1"`
-	Languages    []int                `json:"languages" validate:"required,min=1"`
+	CategoryId   int                  `json:"category_id" validate:"required,taxonomy_id"`
+	Stage        string               `json:"stage" validate:"required,stage"`
+	ProjectType  []int                `json:"project_type" validate:"required,min=1,unique,dive,taxonomy_id"`
+	Technologies []int                `json:"technologies" validate:"required,min=1,unique,dive,taxonomy_id"`
+	Languages    []int                `json:"languages" validate:"required,min=1,unique,dive,taxonomy_id"`
 	ProjectRoles []ProjectRoleRequest `json:"project_roles" validate:"required,dive"`
 }
 
@@ -93,6 +166,7 @@ type ProjectResponse struct {
 	Technologies []Technology  `json:"technologies"`
 	Languages    []Language    `json:"languages"`
 	ProjectRoles []ProjectRole `json:"project_roles"`
+	Version      int           `json:"version" db:"version"`
 }
 
 type ProjectUseCase interface {
@@ -100,37 +174,65 @@ type ProjectUseCase interface {
 	GetById(ctx context.Context, id int) (*ProjectResponse, error)
 	GetByProjectId(ctx context.Context, id int) ([]*ProjectRole, error)
 	List(ctx context.Context, filters map[string]any) ([]ProjectResponse, error)
-	Update(ctx context.Context, req *UpdateProjectRequest, id int) error
+	Update(ctx context.Context, req *UpdateProjectRequest, id int) (*ProjectResponse, error)
+	Patch(ctx context.Context, req *PatchProjectRequest, id int) (*ProjectResponse, error)
 	Delete(ctx context.Context, id int) error
-	GetCategory(ctx context.Context) ([]Category, error)
-	GetTechnology(ctx context.Context) ([]Technology, error)
-	GetLanguage(ctx context.Context) ([]Language, error)
-	GetType(ctx context.Context) ([]Types, error)
+	GetCategory(ctx context.Context, q string) ([]Category, error)
+	GetTechnology(ctx context.Context, q string) ([]Technology, error)
+	GetLanguage(ctx context.Context, q string) ([]Language, error)
+	GetType(ctx context.Context, q string) ([]Types, error)
 }
 
 func (pr *CreateProjectRequest) Validate() error {
-	v := validator.New()
-	err := v.Struct(pr)
-	if err != nil {
-		return err
-	}
-	return nil
+	return validateStruct(pr)
 }
 
 func (prr *ProjectRoleRequest) Validate() error {
-	v := validator.New()
-	err := v.Struct(prr)
-	if err != nil {
+	return validateStruct(prr)
+}
+
+// ValidateUpdate is Validate plus the expected version, which creating a
+// role does not need.
+func (prr *ProjectRoleRequest) ValidateUpdate() error {
+	if err := prr.Validate(); err != nil {
 		return err
 	}
+	if prr.Version < 1 {
+		return &ValidationError{Errors: []FieldError{{Field: "version", Rule: "required", Message: "is required"}}}
+	}
 	return nil
 }
 
 func (upr *UpdateProjectRequest) Validate() error {
-	v := validator.New()
-	err := v.Struct(upr)
-	if err != nil {
+	return validateStruct(upr)
+}
+
+func (ppr *PatchProjectRequest) Validate() error {
+	if err := validateStruct(ppr); err != nil {
 		return err
 	}
+
+	var fields []FieldError
+	for _, l := range []struct {
+		field string
+		patch *LinkPatch
+	}{
+		{"project_type", ppr.ProjectType},
+		{"technologies", ppr.Technologies},
+		{"languages", ppr.Languages},
+	} {
+		if l.patch == nil {
+			continue
+		}
+		for _, id := range l.patch.Add {
+			if slices.Contains(l.patch.Remove, id) {
+				fields = append(fields, FieldError{Field: l.field, Rule: "overlap", Message: "must not add and remove the same id"})
+				break
+			}
+		}
+	}
+	if len(fields) > 0 {
+		return &ValidationError{Errors: fields}
+	}
 	return nil
 }
@@ -2,18 +2,110 @@ package domain
 
 import (
 	"context"
+	"slices"
+	"time"
+)
+
+// Request statuses. A request starts pending, or waitlisted when the role
+// has no seats left; rejected, cancelled, withdrawn, removed and expired are
+// final.
+const (
+	RequestPending   = "pending"
+	RequestAccepted  = "accepted"
+	RequestRejected  = "rejected"
+	RequestCancelled = "cancelled"
+	RequestWithdrawn = "withdrawn"
+	RequestRemoved   = "removed"
+	RequestExpired   = "expired"
+	// RequestWaitlisted requests wait for a seat of a filled role to reopen
+	RequestWaitlisted = "waitlisted"
+)
+
+// requestTransitions lists the statuses each status may move to.
+var requestTransitions = map[string][]string{
+	RequestPending:  {RequestAccepted, RequestRejected, RequestCancelled, RequestExpired},
+	RequestAccepted: {RequestWithdrawn, RequestRemoved},
+	// owners may accept from the waitlist directly once a seat is free
+	RequestWaitlisted: {RequestPending, RequestAccepted, RequestRejected, RequestCancelled},
+}
+
+// CanTransitionRequest reports whether a request may move from one status to
+// another.
+func CanTransitionRequest(from, to string) bool {
+	return slices.Contains(requestTransitions[from], to)
+}
+
+// IsOpenRequest reports whether a request with the status still counts
+// towards the role: pending, waitlisted or accepted.
+func IsOpenRequest(status string) bool {
+	return status == RequestPending || status == RequestWaitlisted || status == RequestAccepted
+}
+
+// OpenApplications counts the applications among requests that are still
+// waiting for a reply, pending or waitlisted. Invitations do not count.
+func OpenApplications(requests []ProjectRequest) int {
+	open := 0
+	for _, r := range requests {
+		if r.Direction == DirectionApplication && (r.Status == RequestPending || r.Status == RequestWaitlisted) {
+			open++
+		}
+	}
+	return open
+}
 
-	"github.com/go-playground/validator/v10"
+// Request directions. Applications are started by the applicant and
+// answered by the owner, invitations the other way around.
+const (
+	DirectionApplication = "application"
+	DirectionInvitation  = "invitation"
 )
 
+// ProjectRequest is an application or invitation for a role. UserId is nil
+// for invitations sent to an email that has no account yet.
 type ProjectRequest struct {
 	Id        int    `json:"id" db:"id"`
 	ProjectId int    `json:"project_id" db:"project_id"`
-	UserId    int    `json:"user_id" db:"user_id"`
+	UserId    *int   `json:"user_id" db:"user_id"`
 	RoleId    int    `json:"role_id" db:"role_id"`
 	Status    string `json:"status" db:"status"`
 	CreatedAt string `json:"created_at" db:"created_at"`
 	UpdatedAt string `json:"updated_at" db:"updated_at"`
+	// PendingSince is when the request last became pending, on creation or
+	// on promotion from the waitlist
+	PendingSince time.Time `json:"-" db:"pending_since"`
+
+	Direction    string     `json:"direction" db:"direction"`
+	InviteeEmail *string    `json:"invitee_email,omitempty" db:"invitee_email"`
+	InvitedBy    *int       `json:"invited_by,omitempty" db:"invited_by"`
+	ExpiresAt    *time.Time `json:"expires_at,omitempty" db:"expires_at"`
+
+	Message *string    `json:"message,omitempty" db:"message"`
+	Links   StringList `json:"links" db:"links"`
+
+	Answers  []RequestAnswer       `json:"answers" db:"-"`
+	Timeline []ProjectRequestEvent `json:"timeline" db:"-"`
+}
+
+// RequestAnswer is an applicant's answer. QuestionId is nil once the
+// question has been deleted, Prompt keeps the text it was asked with.
+type RequestAnswer struct {
+	Id         int    `json:"id" db:"id"`
+	RequestId  int    `json:"request_id" db:"request_id"`
+	QuestionId *int   `json:"question_id" db:"question_id"`
+	Prompt     string `json:"prompt" db:"prompt"`
+	Answer     string `json:"answer" db:"answer"`
+}
+
+// ProjectRequestEvent records one status change of a request. ActorId is
+// nil for changes made by the system, FromStatus is nil for the creation.
+type ProjectRequestEvent struct {
+	Id         int       `json:"id" db:"id"`
+	RequestId  int       `json:"request_id" db:"request_id"`
+	ActorId    *int      `json:"actor_id" db:"actor_id"`
+	FromStatus *string   `json:"from_status" db:"from_status"`
+	ToStatus   string    `json:"to_status" db:"to_status"`
+	Note       *string   `json:"note,omitempty" db:"note"`
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
 }
 
 type ProjectActionRequest struct {
@@ -21,6 +113,31 @@ type ProjectActionRequest struct {
 	UserId    int    `json:"user_id"`
 	RoleId    int    `json:"role_id" validate:"required"`
 	Status    string `json:"status"`
+
+	// only used when applying
+	Message string          `json:"message" validate:"max=5000"`
+	Answers []AnswerRequest `json:"answers" validate:"dive"`
+	Links   []string        `json:"links" validate:"max=5,dive,url"`
+}
+
+type AnswerRequest struct {
+	QuestionId int    `json:"question_id" validate:"required"`
+	Answer     string `json:"answer" validate:"max=2000"`
+}
+
+// InviteRequest invites a registered user by id, or anyone by email.
+type InviteRequest struct {
+	ProjectId int    `json:"project_id" validate:"required"`
+	RoleId    int    `json:"role_id" validate:"required"`
+	UserId    int    `json:"user_id"`
+	Email     string `json:"email" validate:"omitempty,email"`
+	Message   string `json:"message" validate:"max=5000"`
+}
+
+// RemoveMemberRequest is the optional body of removing a member. The
+// reason is shown to the removed user and kept in the request history.
+type RemoveMemberRequest struct {
+	Reason string `json:"reason" validate:"max=255"`
 }
 
 type ProjectActionReplyRequest struct {
@@ -28,28 +145,87 @@ type ProjectActionReplyRequest struct {
 	Accepted  bool `json:"accepted"`
 }
 
+// ApplyLimits cap the pending and waitlisted applications of a user and of
+// a role. 0 is unlimited.
+type ApplyLimits struct {
+	MaxOpenPerUser int
+	MaxOpenPerRole int
+}
+
+// ApplicationUsage is how much of the application limits a user has used.
+// A limit of 0 is unlimited.
+type ApplicationUsage struct {
+	OpenApplications    int `json:"open_applications"`
+	MaxOpenApplications int `json:"max_open_applications"`
+	MaxOpenPerRole      int `json:"max_open_per_role"`
+	// Cooldowns are the roles the user was rejected for recently and cannot
+	// apply to again yet.
+	Cooldowns []ApplyCooldown `json:"cooldowns"`
+}
+
+// ApplyCooldown is the latest rejection of a user for a role. Until is set
+// by the use case from the configured cooldown.
+type ApplyCooldown struct {
+	ProjectId  int       `json:"project_id" db:"project_id"`
+	RoleId     int       `json:"role_id" db:"role_id"`
+	RejectedAt time.Time `json:"rejected_at" db:"rejected_at"`
+	Until      time.Time `json:"until" db:"-"`
+}
+
+// ExpirableRequest is a pending request due to expire, with the titles its
+// notification names.
+type ExpirableRequest struct {
+	ProjectRequest
+	RoleTitle    string `db:"role_title"`
+	ProjectTitle string `db:"project_title"`
+}
+
+// ReviewReminder is a project whose owner has applications waiting for a
+// reply.
+type ReviewReminder struct {
+	ProjectId int `db:"project_id"`
+	OwnerId   int `db:"owner_id"`
+	Pending   int `db:"pending"`
+}
+
 type ProjectActionsUseCase interface {
 	GetById(ctx context.Context, id int) ([]*ProjectRequest, error)
-	ApplyToProject(ctx context.Context, req ProjectActionRequest) error
+	// ApplyToProject returns the status of the new request, pending or
+	// waitlisted.
+	ApplyToProject(ctx context.Context, req ProjectActionRequest) (string, error)
 	CancelRequestToProject(ctx context.Context, req ProjectActionRequest) error
 	WithdrawFromProject(ctx context.Context, req ProjectActionRequest) error
 	ReplyToRequest(ctx context.Context, req ProjectActionReplyRequest) error
+	Invite(ctx context.Context, req *InviteRequest) (*ProjectRequest, error)
+	ListInvitations(ctx context.Context) ([]*ProjectRequest, error)
+	RespondToInvitation(ctx context.Context, id int, accept bool) error
+	CancelInvitation(ctx context.Context, id int) error
+	ListApplicants(ctx context.Context, projectId int, filter ApplicantFilter) ([]*Applicant, error)
+	ReviewApplicant(ctx context.Context, requestId int, req *ReviewApplicantRequest) (*ApplicantReview, error)
+	RemoveMember(ctx context.Context, requestId int, req *RemoveMemberRequest) error
+	ApplicationUsage(ctx context.Context) (*ApplicationUsage, error)
 }
 
 func (r *ProjectActionRequest) Validate() error {
-	v := validator.New()
-	err := v.Struct(r)
-	if err != nil {
-		return err
-	}
-	return nil
+	return validateStruct(r)
 }
 
 func (pr *ProjectActionReplyRequest) Validate() error {
-	v := validator.New()
-	err := v.Struct(pr)
-	if err != nil {
+	return validateStruct(pr)
+}
+
+func (rr *RemoveMemberRequest) Validate() error {
+	return validateStruct(rr)
+}
+
+func (ir *InviteRequest) Validate() error {
+	if err := validateStruct(ir); err != nil {
 		return err
 	}
+	if (ir.UserId == 0) == (ir.Email == "") {
+		return &ValidationError{Errors: []FieldError{
+			{Field: "user_id", Rule: "required_without", Message: "exactly one of user_id and email is required"},
+		}}
+	}
 	return nil
 }
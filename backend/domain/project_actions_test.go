@@ -0,0 +1,47 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanTransitionRequest(t *testing.T) {
+	assert.True(t, CanTransitionRequest(RequestPending, RequestAccepted))
+	assert.True(t, CanTransitionRequest(RequestPending, RequestCancelled))
+	assert.True(t, CanTransitionRequest(RequestAccepted, RequestWithdrawn))
+
+	assert.False(t, CanTransitionRequest(RequestPending, RequestWithdrawn))
+	assert.False(t, CanTransitionRequest(RequestAccepted, RequestCancelled))
+	assert.False(t, CanTransitionRequest(RequestRejected, RequestAccepted))
+	assert.False(t, CanTransitionRequest(RequestExpired, RequestPending))
+
+	// a waitlisted request is promoted, accepted from the list or dropped
+	assert.True(t, CanTransitionRequest(RequestWaitlisted, RequestPending))
+	assert.True(t, CanTransitionRequest(RequestWaitlisted, RequestAccepted))
+	assert.True(t, CanTransitionRequest(RequestWaitlisted, RequestCancelled))
+	assert.False(t, CanTransitionRequest(RequestWaitlisted, RequestExpired))
+	assert.False(t, CanTransitionRequest(RequestAccepted, RequestWaitlisted))
+}
+
+func TestInviteRequest_Validate(t *testing.T) {
+	assert.NoError(t, (&InviteRequest{ProjectId: 1, RoleId: 2, UserId: 3}).Validate())
+	assert.NoError(t, (&InviteRequest{ProjectId: 1, RoleId: 2, Email: "dev@example.com"}).Validate())
+
+	assert.ErrorIs(t, (&InviteRequest{ProjectId: 1, RoleId: 2}).Validate(), ErrValidationFailed)
+	assert.ErrorIs(t, (&InviteRequest{ProjectId: 1, RoleId: 2, UserId: 3, Email: "dev@example.com"}).Validate(), ErrValidationFailed)
+	assert.ErrorIs(t, (&InviteRequest{ProjectId: 1, RoleId: 2, Email: "not-an-email"}).Validate(), ErrValidationFailed)
+}
+
+func TestOpenApplications(t *testing.T) {
+	requests := []ProjectRequest{
+		{Direction: DirectionApplication, Status: RequestPending},
+		{Direction: DirectionApplication, Status: RequestWaitlisted},
+		{Direction: DirectionApplication, Status: RequestAccepted},
+		{Direction: DirectionApplication, Status: RequestRejected},
+		// invitations are not the user's applications
+		{Direction: DirectionInvitation, Status: RequestPending},
+	}
+	assert.Equal(t, 2, OpenApplications(requests))
+	assert.Zero(t, OpenApplications(nil))
+}
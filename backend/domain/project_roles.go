@@ -1,10 +1,151 @@
 package domain
 
-import "context"
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Question kinds. Choice questions are answered with one of their options.
+const (
+	QuestionText   = "text"
+	QuestionChoice = "choice"
+)
+
+// StringList is a list of strings stored as a JSON column.
+type StringList []string
+
+func (l *StringList) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*l = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(v, l)
+	case string:
+		return json.Unmarshal([]byte(v), l)
+	default:
+		return fmt.Errorf("cannot scan %T into StringList", src)
+	}
+}
+
+func (l StringList) Value() (driver.Value, error) {
+	if l == nil {
+		return nil, nil
+	}
+	b, err := json.Marshal([]string(l))
+	return string(b), err
+}
+
+// RoleQuestion is asked to everyone applying for a role.
+type RoleQuestion struct {
+	Id       int        `json:"id" db:"id"`
+	RoleId   int        `json:"role_id" db:"role_id"`
+	Prompt   string     `json:"prompt" db:"prompt"`
+	Kind     string     `json:"kind" db:"kind"`
+	Options  StringList `json:"options,omitempty" db:"options"`
+	Required bool       `json:"required" db:"required"`
+	Position int        `json:"position" db:"position"`
+}
+
+type RoleQuestionRequest struct {
+	Prompt   string   `json:"prompt" validate:"required,max=500"`
+	Kind     string   `json:"kind" validate:"required,oneof=text choice"`
+	Options  []string `json:"options" validate:"max=20,unique,dive,required,max=200"`
+	Required bool     `json:"required"`
+	Position int      `json:"position" validate:"gte=0"`
+}
 
 // project roles domain
 type ProjectRolesUseCase interface {
 	Create(ctx context.Context, req *ProjectRoleRequest) (*ProjectRole, error)
 	Update(ctx context.Context, req *ProjectRoleRequest, id int) (*ProjectRole, error)
 	Delete(ctx context.Context, id int) error
+	ListQuestions(ctx context.Context, roleId int) ([]RoleQuestion, error)
+	CreateQuestion(ctx context.Context, roleId int, req *RoleQuestionRequest) (*RoleQuestion, error)
+	UpdateQuestion(ctx context.Context, roleId int, questionId int, req *RoleQuestionRequest) (*RoleQuestion, error)
+	DeleteQuestion(ctx context.Context, roleId int, questionId int) error
+}
+
+func (qr *RoleQuestionRequest) Validate() error {
+	if err := validateStruct(qr); err != nil {
+		return err
+	}
+
+	if qr.Kind == QuestionChoice && len(qr.Options) < 2 {
+		return &ValidationError{Errors: []FieldError{
+			{Field: "options", Rule: "min", Message: "must contain at least 2 items"},
+		}}
+	}
+	if qr.Kind == QuestionText && len(qr.Options) > 0 {
+		return &ValidationError{Errors: []FieldError{
+			{Field: "options", Rule: "excluded", Message: "must be empty for text questions"},
+		}}
+	}
+	return nil
+}
+
+// ValidateAnswers checks answers against the questions of the role applied
+// for: every answer must belong to a question, at most once, choice answers
+// must be one of the options and required questions must be answered.
+func ValidateAnswers(questions []RoleQuestion, answers []AnswerRequest) error {
+	byId := make(map[int]RoleQuestion, len(questions))
+	for _, q := range questions {
+		byId[q.Id] = q
+	}
+
+	var fields []FieldError
+	seen := make(map[int]bool, len(answers))
+	answered := make(map[int]bool, len(answers))
+	for i, a := range answers {
+		q, ok := byId[a.QuestionId]
+		if !ok {
+			fields = append(fields, FieldError{
+				Field:   fmt.Sprintf("answers[%d].question_id", i),
+				Rule:    "exists",
+				Message: "is not a question of this role",
+			})
+			continue
+		}
+		if seen[q.Id] {
+			fields = append(fields, FieldError{
+				Field:   fmt.Sprintf("answers[%d].question_id", i),
+				Rule:    "unique",
+				Message: "answers the same question twice",
+			})
+			continue
+		}
+		seen[q.Id] = true
+
+		answer := strings.TrimSpace(a.Answer)
+		if answer == "" {
+			continue
+		}
+		answered[q.Id] = true
+		if q.Kind == QuestionChoice && !slices.Contains(q.Options, answer) {
+			fields = append(fields, FieldError{
+				Field:   fmt.Sprintf("answers[%d].answer", i),
+				Rule:    "oneof",
+				Message: "must be one of " + strings.Join(q.Options, ", "),
+			})
+		}
+	}
+
+	for _, q := range questions {
+		if q.Required && !answered[q.Id] {
+			fields = append(fields, FieldError{
+				Field:   "answers",
+				Rule:    "required",
+				Message: fmt.Sprintf("must answer question %d", q.Id),
+			})
+		}
+	}
+
+	if len(fields) > 0 {
+		return &ValidationError{Errors: fields}
+	}
+	return nil
 }
@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateAnswers(t *testing.T) {
+	questions := []RoleQuestion{
+		{Id: 1, Prompt: "Why this project?", Kind: QuestionText, Required: true},
+		{Id: 2, Prompt: "Hours per week", Kind: QuestionChoice, Options: StringList{"<5", "5-10", "10+"}},
+	}
+
+	assert.NoError(t, ValidateAnswers(questions, []AnswerRequest{
+		{QuestionId: 1, Answer: "I like Go"},
+		{QuestionId: 2, Answer: "5-10"},
+	}))
+
+	var ve *ValidationError
+	err := ValidateAnswers(questions, []AnswerRequest{
+		{QuestionId: 2, Answer: "all of them"},
+		{QuestionId: 2, Answer: "<5"},
+		{QuestionId: 9, Answer: "?"},
+		{QuestionId: 1, Answer: "   "},
+	})
+	assert.True(t, errors.As(err, &ve))
+	assert.Equal(t, []FieldError{
+		{Field: "answers[0].answer", Rule: "oneof", Message: "must be one of <5, 5-10, 10+"},
+		{Field: "answers[1].question_id", Rule: "unique", Message: "answers the same question twice"},
+		{Field: "answers[2].question_id", Rule: "exists", Message: "is not a question of this role"},
+		{Field: "answers", Rule: "required", Message: "must answer question 1"},
+	}, ve.Errors)
+}
+
+func TestRoleQuestionRequest_Validate(t *testing.T) {
+	req := &RoleQuestionRequest{Prompt: "Stack?", Kind: QuestionChoice, Options: []string{"Go"}}
+
+	var ve *ValidationError
+	assert.True(t, errors.As(req.Validate(), &ve))
+	assert.Equal(t, "options", ve.Errors[0].Field)
+
+	req.Options = append(req.Options, "Rust")
+	assert.NoError(t, req.Validate())
+}
+
+func TestStringList_Scan(t *testing.T) {
+	var l StringList
+	assert.NoError(t, l.Scan([]byte(`["https://github.com/a"]`)))
+	assert.Equal(t, StringList{"https://github.com/a"}, l)
+
+	assert.NoError(t, l.Scan(nil))
+	assert.Nil(t, l)
+
+	v, err := StringList{"x"}.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, `["x"]`, v)
+}
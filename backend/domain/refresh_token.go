@@ -3,12 +3,11 @@ package domain
 import (
 	"context"
 
-	"github.com/go-playground/validator/v10"
 	"github.com/iemran93/devMatch/bootstrap"
 )
 
 type RefreshTokenRequest struct {
-	RefreshToken string `form:"refreshToken" binding:"required"`
+	RefreshToken string `json:"refreshToken" validate:"required"`
 }
 
 type RefreshTokenResponse struct {
@@ -17,12 +16,7 @@ type RefreshTokenResponse struct {
 }
 
 func (rt *RefreshTokenRequest) Validate() error {
-	v := validator.New()
-	err := v.Struct(rt)
-	if err != nil {
-		return err
-	}
-	return nil
+	return validateStruct(rt)
 }
 
 type RefreshTokenUseCase interface {
@@ -3,7 +3,6 @@ package domain
 import (
 	"context"
 
-	"github.com/go-playground/validator/v10"
 	"github.com/iemran93/devMatch/bootstrap"
 )
 
@@ -19,12 +18,7 @@ type SignupResponse struct {
 }
 
 func (sr *SignupRequest) Validate() error {
-	v := validator.New()
-	err := v.Struct(sr)
-	if err != nil {
-		return err
-	}
-	return nil
+	return validateStruct(sr)
 }
 
 type SignupUseCase interface {
@@ -0,0 +1,131 @@
+package domain
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// TaxonomyKind names one of the lookup tables projects are tagged with.
+type TaxonomyKind string
+
+const (
+	TaxonomyCategory   TaxonomyKind = "category"
+	TaxonomyTechnology TaxonomyKind = "technology"
+	TaxonomyLanguage   TaxonomyKind = "language"
+	TaxonomyType       TaxonomyKind = "type"
+)
+
+func (k TaxonomyKind) Valid() bool {
+	switch k {
+	case TaxonomyCategory, TaxonomyTechnology, TaxonomyLanguage, TaxonomyType:
+		return true
+	}
+	return false
+}
+
+// NormalizeTaxonomyName folds spelling variants such as "Go-Lang", "go lang"
+// and "golang" to one key. Characters that carry meaning in names like "C++"
+// and "C#" are kept.
+func NormalizeTaxonomyName(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '-', '_', '.':
+			return -1
+		}
+		return r
+	}, strings.ToLower(strings.TrimSpace(name)))
+}
+
+// TaxonomyEntry is a lookup row as seen by admins. Usage is the number of
+// projects that reference the entry.
+type TaxonomyEntry struct {
+	Id         int    `json:"id" db:"id"`
+	Name       string `json:"name" db:"name"`
+	Deprecated bool   `json:"deprecated" db:"deprecated"`
+	Usage      int    `json:"usage" db:"usage_count"`
+}
+
+type CreateTaxonomyRequest struct {
+	Name string `json:"name" validate:"required,max=255"`
+}
+
+type UpdateTaxonomyRequest struct {
+	Name       string `json:"name" validate:"required,max=255"`
+	Deprecated bool   `json:"deprecated"`
+}
+
+// MergeTaxonomyRequest moves every reference of the entry in the URL to
+// TargetId and deletes the merged entry.
+type MergeTaxonomyRequest struct {
+	TargetId int `json:"target_id" validate:"required,taxonomy_id"`
+}
+
+// TaxonomyAlias is an alternative spelling that resolves to an entry.
+type TaxonomyAlias struct {
+	Id         int          `json:"id" db:"id"`
+	Kind       TaxonomyKind `json:"kind" db:"kind"`
+	EntryId    int          `json:"entry_id" db:"entry_id"`
+	Alias      string       `json:"alias" db:"alias"`
+	Normalized string       `json:"normalized" db:"normalized"`
+}
+
+type CreateTaxonomyAliasRequest struct {
+	Alias string `json:"alias" validate:"required,max=255"`
+}
+
+const (
+	ProposalPending  = "pending"
+	ProposalApproved = "approved"
+	ProposalRejected = "rejected"
+)
+
+type TechnologyProposal struct {
+	Id           int        `json:"id" db:"id"`
+	Name         string     `json:"name" db:"name"`
+	UserId       int        `json:"user_id" db:"user_id"`
+	Status       string     `json:"status" db:"status"`
+	TechnologyId *int       `json:"technology_id,omitempty" db:"technology_id"`
+	ReviewedBy   *int       `json:"reviewed_by,omitempty" db:"reviewed_by"`
+	CreatedAt    time.Time  `json:"created_at" db:"created_at"`
+	ReviewedAt   *time.Time `json:"reviewed_at,omitempty" db:"reviewed_at"`
+	// PendingName is the normalized name while the proposal is pending
+	PendingName *string `json:"-" db:"pending_name"`
+}
+
+type ProposeTechnologyRequest struct {
+	Name string `json:"name" validate:"required,max=255"`
+}
+
+type TaxonomyUseCase interface {
+	List(ctx context.Context, kind TaxonomyKind) ([]TaxonomyEntry, error)
+	Create(ctx context.Context, kind TaxonomyKind, req *CreateTaxonomyRequest) (*TaxonomyEntry, error)
+	Update(ctx context.Context, kind TaxonomyKind, id int, req *UpdateTaxonomyRequest) (*TaxonomyEntry, error)
+	Merge(ctx context.Context, kind TaxonomyKind, id int, req *MergeTaxonomyRequest) error
+	ListAliases(ctx context.Context, kind TaxonomyKind, id int) ([]TaxonomyAlias, error)
+	CreateAlias(ctx context.Context, kind TaxonomyKind, id int, req *CreateTaxonomyAliasRequest) (*TaxonomyAlias, error)
+	DeleteAlias(ctx context.Context, kind TaxonomyKind, id int, aliasId int) error
+	ProposeTechnology(ctx context.Context, req *ProposeTechnologyRequest) (*TechnologyProposal, error)
+	ListProposals(ctx context.Context, status string) ([]TechnologyProposal, error)
+	ReviewProposal(ctx context.Context, id int, approve bool) (*TechnologyProposal, error)
+}
+
+func (r *CreateTaxonomyRequest) Validate() error {
+	return validateStruct(r)
+}
+
+func (r *UpdateTaxonomyRequest) Validate() error {
+	return validateStruct(r)
+}
+
+func (r *MergeTaxonomyRequest) Validate() error {
+	return validateStruct(r)
+}
+
+func (r *CreateTaxonomyAliasRequest) Validate() error {
+	return validateStruct(r)
+}
+
+func (r *ProposeTechnologyRequest) Validate() error {
+	return validateStruct(r)
+}
@@ -0,0 +1,23 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeTaxonomyName(t *testing.T) {
+	tests := map[string]string{
+		"golang":   "golang",
+		"Go-Lang":  "golang",
+		" go lang": "golang",
+		"Node.js":  "nodejs",
+		"C++":      "c++",
+		"C#":       "c#",
+		"vue_js":   "vuejs",
+	}
+
+	for in, want := range tests {
+		assert.Equal(t, want, NormalizeTaxonomyName(in), in)
+	}
+}
@@ -16,6 +16,7 @@ type User struct {
 	Availability   bool           `json:"availability" db:"availability"`
 	CreatedAt      time.Time      `json:"created_at" db:"created_at"`
 	UpdatedAt      time.Time      `json:"updated_at" db:"updated_at"`
+	IsAdmin        bool           `json:"is_admin" db:"is_admin"`
 }
 
 type UserResponse struct {
@@ -26,6 +27,7 @@ type UserResponse struct {
 	Email          string    `json:"email" db:"email"`
 	Availability   bool      `json:"availability" db:"availability"`
 	CreatedAt      time.Time `json:"created_at" db:"created_at"`
+	IsAdmin        bool      `json:"is_admin,omitempty" db:"is_admin"`
 }
 
 type UserUseCase interface {
@@ -0,0 +1,151 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// ProjectStages are the values accepted by the "stage" rule.
+var ProjectStages = []string{"Idea", "In Progress", "Completed"}
+
+// FieldError describes one failed rule on one request field. Field uses the
+// JSON name of the field, including the path for nested values, e.g.
+// "project_roles[0].title".
+type FieldError struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+// ValidationError carries every field that failed validation. It unwraps to
+// ErrValidationFailed so it is reported like any other domain error.
+type ValidationError struct {
+	Errors []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, fe := range e.Errors {
+		msgs[i] = fe.Field + " " + fe.Message
+	}
+	return strings.Join(msgs, "; ")
+}
+
+func (e *ValidationError) Unwrap() error {
+	return ErrValidationFailed
+}
+
+// validate is shared by every request DTO; validator caches struct metadata
+// per instance, so building one per call throws that work away.
+var validate = newValidator()
+
+func newValidator() *validator.Validate {
+	v := validator.New(validator.WithRequiredStructEnabled())
+
+	v.RegisterTagNameFunc(func(f reflect.StructField) string {
+		name := strings.SplitN(f.Tag.Get("json"), ",", 2)[0]
+		if name == "-" {
+			return ""
+		}
+		if name == "" {
+			return f.Name
+		}
+		return name
+	})
+
+	v.RegisterValidation("stage", func(fl validator.FieldLevel) bool {
+		stage := fl.Field().String()
+		for _, s := range ProjectStages {
+			if stage == s {
+				return true
+			}
+		}
+		return false
+	})
+
+	v.RegisterValidation("taxonomy_id", func(fl validator.FieldLevel) bool {
+		return fl.Field().Int() > 0
+	})
+
+	return v
+}
+
+// validateStruct runs the shared validator and converts its errors into a
+// ValidationError.
+func validateStruct(s any) error {
+	err := validate.Struct(s)
+	if err == nil {
+		return nil
+	}
+
+	var ves validator.ValidationErrors
+	if !errors.As(err, &ves) {
+		return err
+	}
+
+	fields := make([]FieldError, len(ves))
+	for i, fe := range ves {
+		fields[i] = FieldError{
+			Field:   fieldPath(fe),
+			Rule:    fe.Tag(),
+			Message: fieldMessage(fe),
+		}
+	}
+	return &ValidationError{Errors: fields}
+}
+
+// fieldPath drops the top level struct name from the namespace.
+func fieldPath(fe validator.FieldError) string {
+	ns := fe.Namespace()
+	if i := strings.Index(ns, "."); i >= 0 {
+		return ns[i+1:]
+	}
+	return ns
+}
+
+func fieldMessage(fe validator.FieldError) string {
+	collection := false
+	switch fe.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		collection = true
+	}
+
+	switch fe.Tag() {
+	case "required":
+		return "is required"
+	case "email":
+		return "must be a valid email address"
+	case "url":
+		return "must be a valid URL"
+	case "min":
+		if collection {
+			return fmt.Sprintf("must contain at least %s items", fe.Param())
+		}
+		if fe.Kind() == reflect.String {
+			return fmt.Sprintf("must be at least %s characters long", fe.Param())
+		}
+		return fmt.Sprintf("must be at least %s", fe.Param())
+	case "max":
+		if collection {
+			return fmt.Sprintf("must contain at most %s items", fe.Param())
+		}
+		if fe.Kind() == reflect.String {
+			return fmt.Sprintf("must be at most %s characters long", fe.Param())
+		}
+		return fmt.Sprintf("must be at most %s", fe.Param())
+	case "oneof":
+		return "must be one of " + strings.Join(strings.Fields(fe.Param()), ", ")
+	case "unique":
+		return "must not contain duplicates"
+	case "stage":
+		return "must be one of " + strings.Join(ProjectStages, ", ")
+	case "taxonomy_id":
+		return "must be a positive id"
+	default:
+		return fmt.Sprintf("failed the %q rule", fe.Tag())
+	}
+}
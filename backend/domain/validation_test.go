@@ -0,0 +1,138 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate_FieldErrors(t *testing.T) {
+	req := &CreateProjectRequest{
+		Title:        "devMatch",
+		CategoryId:   1,
+		Stage:        "Done",
+		ProjectType:  []int{1},
+		Technologies: []int{2, 0},
+		Languages:    []int{},
+		ProjectRoles: []ProjectRoleRequest{{Description: "no title"}},
+	}
+
+	err := req.Validate()
+
+	var ve *ValidationError
+	assert.True(t, errors.As(err, &ve))
+	assert.ErrorIs(t, err, ErrValidationFailed)
+	assert.ElementsMatch(t, []FieldError{
+		{Field: "description", Rule: "required", Message: "is required"},
+		{Field: "stage", Rule: "stage", Message: "must be one of Idea, In Progress, Completed"},
+		{Field: "technologies[1]", Rule: "taxonomy_id", Message: "must be a positive id"},
+		{Field: "languages", Rule: "min", Message: "must contain at least 1 items"},
+		{Field: "project_roles[0].title", Rule: "required", Message: "is required"},
+	}, ve.Errors)
+}
+
+func TestValidate_DuplicateIds(t *testing.T) {
+	req := &UpdateProjectRequest{
+		Version:      1,
+		Title:        "devMatch",
+		Description:  "match developers",
+		CategoryId:   1,
+		Stage:        "Idea",
+		ProjectType:  []int{1},
+		Technologies: []int{3, 3},
+		Languages:    []int{1},
+	}
+
+	var ve *ValidationError
+	assert.True(t, errors.As(req.Validate(), &ve))
+	assert.Equal(t, []FieldError{
+		{Field: "technologies", Rule: "unique", Message: "must not contain duplicates"},
+	}, ve.Errors)
+}
+
+func TestValidateUpdate_RequiresVersion(t *testing.T) {
+	req := &ProjectRoleRequest{Title: "backend"}
+	assert.NoError(t, req.Validate())
+
+	var ve *ValidationError
+	assert.True(t, errors.As(req.ValidateUpdate(), &ve))
+	assert.Equal(t, []FieldError{
+		{Field: "version", Rule: "required", Message: "is required"},
+	}, ve.Errors)
+
+	req.Version = 2
+	assert.NoError(t, req.ValidateUpdate())
+}
+
+func TestPatchProjectRequest_Validate(t *testing.T) {
+	empty := ""
+	req := &PatchProjectRequest{
+		Version:      3,
+		Title:        &empty,
+		Technologies: &LinkPatch{Add: []int{1, 2}, Remove: []int{2}},
+	}
+
+	var ve *ValidationError
+	assert.True(t, errors.As(req.Validate(), &ve))
+	assert.Equal(t, []FieldError{
+		{Field: "title", Rule: "min", Message: "must be at least 1 characters long"},
+	}, ve.Errors)
+
+	title := "devMatch"
+	req.Title = &title
+	assert.True(t, errors.As(req.Validate(), &ve))
+	assert.Equal(t, []FieldError{
+		{Field: "technologies", Rule: "overlap", Message: "must not add and remove the same id"},
+	}, ve.Errors)
+
+	req.Technologies.Remove = []int{3}
+	assert.NoError(t, req.Validate())
+}
+
+func TestValidate_Success(t *testing.T) {
+	req := &LoginRequest{Email: "john@example.com", Password: "secret"}
+
+	assert.NoError(t, req.Validate())
+}
+
+func TestProjectRoleRequest_Seats(t *testing.T) {
+	req := &ProjectRoleRequest{Title: "backend", Seats: 101}
+
+	var ve *ValidationError
+	assert.True(t, errors.As(req.Validate(), &ve))
+	assert.Equal(t, []FieldError{
+		{Field: "seats", Rule: "max", Message: "must be at most 100"},
+	}, ve.Errors)
+
+	// 0 leaves the default to the repository
+	req.Seats = 0
+	assert.NoError(t, req.Validate())
+}
+
+func TestProjectRoleRequest_Skills(t *testing.T) {
+	req := &ProjectRoleRequest{
+		Title:           "backend",
+		ExperienceLevel: "expert",
+		Technologies:    []RoleSkillRequest{{Id: 1, Required: true}, {Id: 1}},
+		Languages:       []RoleSkillRequest{{Id: 0}},
+	}
+
+	var ve *ValidationError
+	assert.True(t, errors.As(req.Validate(), &ve))
+	fields := make([]string, len(ve.Errors))
+	for i, e := range ve.Errors {
+		fields[i] = e.Field + ":" + e.Rule
+	}
+	assert.ElementsMatch(t, []string{
+		"experience_level:oneof",
+		"technologies:unique",
+		"languages[0].id:taxonomy_id",
+	}, fields)
+
+	// an empty level leaves the default to the repository
+	req = &ProjectRoleRequest{Title: "backend", Languages: []RoleSkillRequest{{Id: 2}}}
+	assert.NoError(t, req.Validate())
+	req.ExperienceLevel = ExperienceSenior
+	assert.NoError(t, req.Validate())
+}
@@ -0,0 +1,56 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLRU_EvictsLeastRecentlyUsed(t *testing.T) {
+	c := NewLRU[int, string](2, 0)
+	c.Set(1, "one")
+	c.Set(2, "two")
+
+	// touch 1 so 2 becomes the eviction candidate
+	_, ok := c.Get(1)
+	assert.True(t, ok)
+
+	c.Set(3, "three")
+
+	_, ok = c.Get(2)
+	assert.False(t, ok)
+	v, ok := c.Get(1)
+	assert.True(t, ok)
+	assert.Equal(t, "one", v)
+	assert.Equal(t, 2, c.Len())
+}
+
+func TestLRU_Expires(t *testing.T) {
+	now := time.Unix(0, 0)
+	c := NewLRU[string, int](10, time.Minute)
+	c.now = func() time.Time { return now }
+
+	c.Set("a", 1)
+	now = now.Add(59 * time.Second)
+	_, ok := c.Get("a")
+	assert.True(t, ok)
+
+	now = now.Add(time.Second)
+	_, ok = c.Get("a")
+	assert.False(t, ok)
+	assert.Equal(t, 0, c.Len())
+}
+
+func TestLRU_DeleteAndPurge(t *testing.T) {
+	c := NewLRU[int, int](10, 0)
+	c.Set(1, 1)
+	c.Set(2, 2)
+
+	c.Delete(1)
+	_, ok := c.Get(1)
+	assert.False(t, ok)
+
+	c.Purge()
+	assert.Equal(t, 0, c.Len())
+}
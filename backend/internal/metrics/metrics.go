@@ -0,0 +1,66 @@
+package metrics
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "devmatch"
+
+// HTTP metrics, labelled by the mux route template rather than the raw path
+// to keep cardinality bounded.
+var (
+	HTTPRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "Number of HTTP requests by route, method and status code.",
+	}, []string{"route", "method", "status"})
+
+	HTTPRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "HTTP request latency by route and method.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"route", "method"})
+)
+
+// Domain metrics
+var (
+	ProjectsCreated = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "projects_created_total",
+		Help:      "Number of projects created.",
+	})
+
+	ProjectRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "project_requests_total",
+		Help:      "Number of project request events by action (applied, accepted, rejected, cancelled, withdrawn).",
+	}, []string{"action"})
+
+	Signups = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "signups_total",
+		Help:      "Number of user signups by provider.",
+	}, []string{"provider"})
+
+	SchedulerRuns = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "scheduler_runs_total",
+		Help:      "Number of scheduled job runs by job and result (ok, error, skipped).",
+	}, []string{"job", "result"})
+)
+
+// RegisterDB exports the connection pool statistics of db.
+func RegisterDB(db *sql.DB, dbName string) {
+	prometheus.MustRegister(collectors.NewDBStatsCollector(db, dbName))
+}
+
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
@@ -0,0 +1,153 @@
+// Package scheduler runs periodic background jobs inside the API process.
+// Every replica runs a scheduler; a lease per job makes sure only one of
+// them runs it each interval.
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/iemran93/devMatch/internal/metrics"
+	log "github.com/sirupsen/logrus"
+)
+
+// Job is run every Interval. now is the scheduler's clock at the time the
+// run was due, so jobs never read the wall clock themselves.
+type Job struct {
+	Name     string
+	Interval time.Duration
+	Run      func(ctx context.Context, now time.Time) error
+}
+
+// Leaser hands out named, time limited leases. Acquire reports whether
+// owner holds name until now+ttl: it succeeds when the lease is free,
+// expired or already held by owner.
+type Leaser interface {
+	Acquire(ctx context.Context, name string, owner string, now time.Time, ttl time.Duration) (bool, error)
+}
+
+type entry struct {
+	job  Job
+	next time.Time
+}
+
+// Scheduler checks every tick which jobs are due and runs them one after
+// the other. It is not meant for jobs that must run more often than tick.
+type Scheduler struct {
+	leaser Leaser
+	owner  string
+	tick   time.Duration
+	now    func() time.Time
+
+	mu     sync.Mutex
+	jobs   []*entry
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+func New(leaser Leaser, tick time.Duration) *Scheduler {
+	host, err := os.Hostname()
+	if err != nil {
+		host = "unknown"
+	}
+	return &Scheduler{
+		leaser: leaser,
+		owner:  fmt.Sprintf("%s-%d", host, os.Getpid()),
+		tick:   tick,
+		now:    time.Now,
+	}
+}
+
+// Add registers job. Its first run is due on the next tick.
+func (s *Scheduler) Add(job Job) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.jobs = append(s.jobs, &entry{job: job})
+}
+
+// Start runs the scheduler in the background until Stop is called.
+func (s *Scheduler) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+	s.done = make(chan struct{})
+
+	go func() {
+		defer close(s.done)
+		ticker := time.NewTicker(s.tick)
+		defer ticker.Stop()
+
+		s.runDue(ctx)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.runDue(ctx)
+			}
+		}
+	}()
+	log.Infof("scheduler started with %d jobs", len(s.jobs))
+}
+
+// Stop cancels a running job and waits for the scheduler to exit.
+func (s *Scheduler) Stop() {
+	if s.cancel == nil {
+		return
+	}
+	s.cancel()
+	<-s.done
+	log.Info("scheduler stopped")
+}
+
+// runDue runs every job whose next run is due. A job is rescheduled a full
+// interval later whether it ran, lost the lease to another replica or
+// failed.
+func (s *Scheduler) runDue(ctx context.Context) {
+	s.mu.Lock()
+	jobs := make([]*entry, len(s.jobs))
+	copy(jobs, s.jobs)
+	s.mu.Unlock()
+
+	for _, e := range jobs {
+		if ctx.Err() != nil {
+			return
+		}
+		now := s.now()
+		if now.Before(e.next) {
+			continue
+		}
+		e.next = now.Add(e.job.Interval)
+		s.run(ctx, e.job, now)
+	}
+}
+
+func (s *Scheduler) run(ctx context.Context, job Job, now time.Time) {
+	logger := log.WithField("job", job.Name)
+
+	if s.leaser != nil {
+		// the lease lasts one interval, so other replicas skip this run
+		acquired, err := s.leaser.Acquire(ctx, job.Name, s.owner, now, job.Interval)
+		if err != nil {
+			logger.Error("failed to acquire lease: ", err)
+			metrics.SchedulerRuns.WithLabelValues(job.Name, "error").Inc()
+			return
+		}
+		if !acquired {
+			metrics.SchedulerRuns.WithLabelValues(job.Name, "skipped").Inc()
+			return
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, job.Interval)
+	defer cancel()
+
+	if err := job.Run(ctx, now); err != nil {
+		logger.Error("job failed: ", err)
+		metrics.SchedulerRuns.WithLabelValues(job.Name, "error").Inc()
+		return
+	}
+	metrics.SchedulerRuns.WithLabelValues(job.Name, "ok").Inc()
+}
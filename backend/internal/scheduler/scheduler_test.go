@@ -0,0 +1,125 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// memoryLeaser keeps leases in a map, shared by schedulers acting as
+// different replicas.
+type memoryLeaser struct {
+	owners  map[string]string
+	expires map[string]time.Time
+}
+
+func newMemoryLeaser() *memoryLeaser {
+	return &memoryLeaser{owners: map[string]string{}, expires: map[string]time.Time{}}
+}
+
+func (l *memoryLeaser) Acquire(ctx context.Context, name string, owner string, now time.Time, ttl time.Duration) (bool, error) {
+	if l.owners[name] != owner && now.Before(l.expires[name]) {
+		return false, nil
+	}
+	l.owners[name] = owner
+	l.expires[name] = now.Add(ttl)
+	return true, nil
+}
+
+func newTestScheduler(leaser Leaser, owner string, now *time.Time) *Scheduler {
+	s := New(leaser, time.Second)
+	s.owner = owner
+	s.now = func() time.Time { return *now }
+	return s
+}
+
+func TestScheduler_RunsDueJobs(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := newTestScheduler(nil, "a", &now)
+
+	var runs []time.Time
+	s.Add(Job{Name: "count", Interval: time.Hour, Run: func(ctx context.Context, at time.Time) error {
+		runs = append(runs, at)
+		return nil
+	}})
+
+	s.runDue(context.Background())
+	now = now.Add(30 * time.Minute)
+	s.runDue(context.Background())
+	now = now.Add(30 * time.Minute)
+	s.runDue(context.Background())
+
+	assert.Equal(t, []time.Time{
+		time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 1, 1, 1, 0, 0, 0, time.UTC),
+	}, runs)
+}
+
+func TestScheduler_FailedJobIsRetriedNextInterval(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := newTestScheduler(nil, "a", &now)
+
+	calls := 0
+	s.Add(Job{Name: "fail", Interval: time.Minute, Run: func(ctx context.Context, at time.Time) error {
+		calls++
+		return errors.New("boom")
+	}})
+
+	s.runDue(context.Background())
+	s.runDue(context.Background())
+	assert.Equal(t, 1, calls)
+
+	now = now.Add(time.Minute)
+	s.runDue(context.Background())
+	assert.Equal(t, 2, calls)
+}
+
+func TestScheduler_LeaseRunsJobOnOneReplica(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	leaser := newMemoryLeaser()
+
+	runs := map[string]int{}
+	replicas := []*Scheduler{
+		newTestScheduler(leaser, "a", &now),
+		newTestScheduler(leaser, "b", &now),
+	}
+	for _, s := range replicas {
+		owner := s.owner
+		s.Add(Job{Name: "expire", Interval: time.Hour, Run: func(ctx context.Context, at time.Time) error {
+			runs[owner]++
+			return nil
+		}})
+	}
+
+	for _, s := range replicas {
+		s.runDue(context.Background())
+	}
+	assert.Equal(t, map[string]int{"a": 1}, runs)
+
+	// after the lease expires whichever replica comes first takes it
+	now = now.Add(time.Hour)
+	replicas[1].runDue(context.Background())
+	replicas[0].runDue(context.Background())
+	assert.Equal(t, map[string]int{"a": 1, "b": 1}, runs)
+}
+
+func TestScheduler_StopWaitsForRunningJob(t *testing.T) {
+	s := New(nil, time.Hour)
+
+	started := make(chan struct{})
+	finished := false
+	s.Add(Job{Name: "slow", Interval: time.Hour, Run: func(ctx context.Context, at time.Time) error {
+		close(started)
+		<-ctx.Done()
+		finished = true
+		return ctx.Err()
+	}})
+
+	s.Start()
+	<-started
+	s.Stop()
+	assert.True(t, finished)
+}
@@ -0,0 +1,8 @@
+DROP TABLE TechnologyProposal;
+
+ALTER TABLE Types DROP COLUMN deprecated;
+ALTER TABLE Language DROP COLUMN deprecated;
+ALTER TABLE Technology DROP COLUMN deprecated;
+ALTER TABLE Category DROP COLUMN deprecated;
+
+ALTER TABLE User DROP COLUMN is_admin;
@@ -0,0 +1,25 @@
+ALTER TABLE User
+  ADD COLUMN is_admin boolean NOT NULL DEFAULT false;
+
+ALTER TABLE Category ADD COLUMN deprecated boolean NOT NULL DEFAULT false;
+ALTER TABLE Technology ADD COLUMN deprecated boolean NOT NULL DEFAULT false;
+ALTER TABLE Language ADD COLUMN deprecated boolean NOT NULL DEFAULT false;
+ALTER TABLE Types ADD COLUMN deprecated boolean NOT NULL DEFAULT false;
+
+CREATE TABLE TechnologyProposal (
+    id int PRIMARY KEY AUTO_INCREMENT,
+    name varchar(255) NOT NULL,
+    user_id int NOT NULL,
+    status ENUM(
+        'pending',
+        'approved',
+        'rejected'
+    ) NOT NULL DEFAULT 'pending',
+    technology_id int,
+    reviewed_by int,
+    created_at datetime DEFAULT CURRENT_TIMESTAMP,
+    reviewed_at datetime,
+    CONSTRAINT fk_technologyproposal_user FOREIGN KEY (user_id) REFERENCES User (id),
+    CONSTRAINT fk_technologyproposal_technology FOREIGN KEY (technology_id) REFERENCES Technology (id),
+    CONSTRAINT fk_technologyproposal_reviewer FOREIGN KEY (reviewed_by) REFERENCES User (id)
+);
@@ -0,0 +1,3 @@
+-- seeded lookup rows may already be referenced by projects, so only the
+-- alias table is removed
+DROP TABLE TaxonomyAlias;
@@ -0,0 +1,140 @@
+CREATE TABLE TaxonomyAlias (
+    id int PRIMARY KEY AUTO_INCREMENT,
+    kind ENUM(
+        'category',
+        'technology',
+        'language',
+        'type'
+    ) NOT NULL,
+    entry_id int NOT NULL,
+    alias varchar(255) NOT NULL,
+    -- lower case with spaces, dots, dashes and underscores removed
+    normalized varchar(255) NOT NULL,
+    UNIQUE KEY kind_normalized_unique (kind, normalized),
+    KEY kind_entry (kind, entry_id)
+);
+
+INSERT IGNORE INTO Category (name) VALUES
+    ('Web Development'),
+    ('Mobile Development'),
+    ('Desktop Applications'),
+    ('Data Science'),
+    ('Machine Learning'),
+    ('DevOps'),
+    ('Game Development'),
+    ('Embedded Systems'),
+    ('Security'),
+    ('Blockchain'),
+    ('Developer Tools');
+
+INSERT IGNORE INTO Technology (name) VALUES
+    ('React'),
+    ('Vue.js'),
+    ('Angular'),
+    ('Svelte'),
+    ('Next.js'),
+    ('Node.js'),
+    ('Express'),
+    ('Django'),
+    ('Flask'),
+    ('FastAPI'),
+    ('Spring Boot'),
+    ('Ruby on Rails'),
+    ('Laravel'),
+    ('.NET'),
+    ('Flutter'),
+    ('React Native'),
+    ('Tailwind CSS'),
+    ('GraphQL'),
+    ('PostgreSQL'),
+    ('MySQL'),
+    ('MongoDB'),
+    ('Redis'),
+    ('Docker'),
+    ('Kubernetes'),
+    ('Terraform'),
+    ('AWS'),
+    ('Google Cloud'),
+    ('Azure'),
+    ('TensorFlow'),
+    ('PyTorch');
+
+INSERT IGNORE INTO Language (name) VALUES
+    ('Go'),
+    ('Python'),
+    ('JavaScript'),
+    ('TypeScript'),
+    ('Java'),
+    ('Kotlin'),
+    ('Swift'),
+    ('C'),
+    ('C++'),
+    ('C#'),
+    ('Rust'),
+    ('Ruby'),
+    ('PHP'),
+    ('Dart'),
+    ('Scala'),
+    ('Elixir'),
+    ('SQL'),
+    ('HTML'),
+    ('CSS');
+
+INSERT IGNORE INTO Types (name) VALUES
+    ('Open Source'),
+    ('Startup'),
+    ('Hackathon'),
+    ('Learning'),
+    ('Portfolio'),
+    ('Research'),
+    ('Nonprofit'),
+    ('Commercial');
+
+INSERT IGNORE INTO TaxonomyAlias (kind, entry_id, alias, normalized)
+SELECT 'language', l.id, a.alias, a.normalized
+FROM Language l
+JOIN (
+    SELECT 'Go' AS name, 'golang' AS alias, 'golang' AS normalized
+    UNION ALL SELECT 'JavaScript', 'js', 'js'
+    UNION ALL SELECT 'JavaScript', 'ECMAScript', 'ecmascript'
+    UNION ALL SELECT 'TypeScript', 'ts', 'ts'
+    UNION ALL SELECT 'Python', 'py', 'py'
+    UNION ALL SELECT 'Python', 'python3', 'python3'
+    UNION ALL SELECT 'C++', 'cpp', 'cpp'
+    UNION ALL SELECT 'C#', 'csharp', 'csharp'
+    UNION ALL SELECT 'Kotlin', 'kt', 'kt'
+    UNION ALL SELECT 'Rust', 'rustlang', 'rustlang'
+) a ON a.name = l.name;
+
+INSERT IGNORE INTO TaxonomyAlias (kind, entry_id, alias, normalized)
+SELECT 'technology', t.id, a.alias, a.normalized
+FROM Technology t
+JOIN (
+    SELECT 'React' AS name, 'ReactJS' AS alias, 'reactjs' AS normalized
+    UNION ALL SELECT 'Vue.js', 'Vue', 'vue'
+    UNION ALL SELECT 'Vue.js', 'VueJS', 'vuejs'
+    UNION ALL SELECT 'Next.js', 'NextJS', 'nextjs'
+    UNION ALL SELECT 'Node.js', 'Node', 'node'
+    UNION ALL SELECT 'Node.js', 'NodeJS', 'nodejs'
+    UNION ALL SELECT 'Express', 'Express.js', 'expressjs'
+    UNION ALL SELECT 'Ruby on Rails', 'Rails', 'rails'
+    UNION ALL SELECT 'Ruby on Rails', 'RoR', 'ror'
+    UNION ALL SELECT '.NET', 'dotnet', 'dotnet'
+    UNION ALL SELECT 'PostgreSQL', 'Postgres', 'postgres'
+    UNION ALL SELECT 'MongoDB', 'Mongo', 'mongo'
+    UNION ALL SELECT 'Kubernetes', 'k8s', 'k8s'
+    UNION ALL SELECT 'Google Cloud', 'GCP', 'gcp'
+    UNION ALL SELECT 'AWS', 'Amazon Web Services', 'amazonwebservices'
+    UNION ALL SELECT 'Tailwind CSS', 'Tailwind', 'tailwind'
+) a ON a.name = t.name;
+
+INSERT IGNORE INTO TaxonomyAlias (kind, entry_id, alias, normalized)
+SELECT 'category', c.id, a.alias, a.normalized
+FROM Category c
+JOIN (
+    SELECT 'Machine Learning' AS name, 'ML' AS alias, 'ml' AS normalized
+    UNION ALL SELECT 'Machine Learning', 'AI', 'ai'
+    UNION ALL SELECT 'Web Development', 'Web', 'web'
+    UNION ALL SELECT 'Mobile Development', 'Mobile', 'mobile'
+    UNION ALL SELECT 'Game Development', 'Gamedev', 'gamedev'
+) a ON a.name = c.name;
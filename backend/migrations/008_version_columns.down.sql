@@ -0,0 +1,2 @@
+ALTER TABLE ProjectRole DROP COLUMN version;
+ALTER TABLE Project DROP COLUMN version;
@@ -0,0 +1,2 @@
+ALTER TABLE Project ADD COLUMN version int NOT NULL DEFAULT 1;
+ALTER TABLE ProjectRole ADD COLUMN version int NOT NULL DEFAULT 1;
@@ -0,0 +1,12 @@
+DROP TABLE ProjectRequestEvent;
+
+-- the old schema deleted cancelled and withdrawn requests
+DELETE FROM ProjectRequest WHERE status IN ('cancelled', 'withdrawn');
+UPDATE ProjectRequest SET status = 'rejected' WHERE status IN ('removed', 'expired');
+
+ALTER TABLE ProjectRequest
+  MODIFY COLUMN status ENUM(
+    'pending',
+    'accepted',
+    'rejected'
+  ) NOT NULL DEFAULT 'pending';
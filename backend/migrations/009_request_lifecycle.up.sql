@@ -0,0 +1,30 @@
+ALTER TABLE ProjectRequest
+  MODIFY COLUMN status ENUM(
+    'pending',
+    'accepted',
+    'rejected',
+    'cancelled',
+    'withdrawn',
+    'removed',
+    'expired'
+  ) NOT NULL DEFAULT 'pending';
+
+CREATE TABLE ProjectRequestEvent (
+    id int PRIMARY KEY AUTO_INCREMENT,
+    request_id int NOT NULL,
+    actor_id int,
+    from_status varchar(20),
+    to_status varchar(20) NOT NULL,
+    note varchar(255),
+    created_at datetime DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_projectrequestevent_request (request_id, id),
+    CONSTRAINT fk_projectrequestevent_request FOREIGN KEY (request_id) REFERENCES ProjectRequest (id) ON DELETE CASCADE,
+    CONSTRAINT fk_projectrequestevent_actor FOREIGN KEY (actor_id) REFERENCES User (id)
+);
+
+-- history starts with the current state of existing requests
+INSERT INTO ProjectRequestEvent (request_id, actor_id, from_status, to_status, created_at)
+SELECT id, user_id, NULL, 'pending', created_at FROM ProjectRequest;
+
+INSERT INTO ProjectRequestEvent (request_id, actor_id, from_status, to_status, created_at)
+SELECT id, NULL, 'pending', status, updated_at FROM ProjectRequest WHERE status <> 'pending';
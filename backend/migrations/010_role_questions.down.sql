@@ -0,0 +1,7 @@
+DROP TABLE ProjectRequestAnswer;
+
+ALTER TABLE ProjectRequest
+  DROP COLUMN links,
+  DROP COLUMN message;
+
+DROP TABLE ProjectRoleQuestion;
@@ -0,0 +1,25 @@
+CREATE TABLE ProjectRoleQuestion (
+    id int PRIMARY KEY AUTO_INCREMENT,
+    role_id int NOT NULL,
+    prompt varchar(500) NOT NULL,
+    kind ENUM('text', 'choice') NOT NULL DEFAULT 'text',
+    options json,
+    required boolean NOT NULL DEFAULT false,
+    position int NOT NULL DEFAULT 0,
+    CONSTRAINT fk_projectrolequestion_role FOREIGN KEY (role_id) REFERENCES ProjectRole (id) ON DELETE CASCADE
+);
+
+ALTER TABLE ProjectRequest
+  ADD COLUMN message text,
+  ADD COLUMN links json;
+
+-- the prompt is copied so answers stay readable when a question changes
+CREATE TABLE ProjectRequestAnswer (
+    id int PRIMARY KEY AUTO_INCREMENT,
+    request_id int NOT NULL,
+    question_id int,
+    prompt varchar(500) NOT NULL,
+    answer text NOT NULL,
+    CONSTRAINT fk_projectrequestanswer_request FOREIGN KEY (request_id) REFERENCES ProjectRequest (id) ON DELETE CASCADE,
+    CONSTRAINT fk_projectrequestanswer_question FOREIGN KEY (question_id) REFERENCES ProjectRoleQuestion (id) ON DELETE SET NULL
+);
@@ -0,0 +1,11 @@
+-- invitations to unregistered emails have no user and cannot be kept
+DELETE FROM ProjectRequest WHERE user_id IS NULL;
+
+ALTER TABLE ProjectRequest
+  DROP FOREIGN KEY fk_projectrequest_invited_by,
+  DROP INDEX idx_projectrequest_invitee_email,
+  DROP COLUMN expires_at,
+  DROP COLUMN invited_by,
+  DROP COLUMN invitee_email,
+  DROP COLUMN direction,
+  MODIFY COLUMN user_id int NOT NULL;
@@ -0,0 +1,8 @@
+ALTER TABLE ProjectRequest
+  MODIFY COLUMN user_id int NULL,
+  ADD COLUMN direction ENUM('application', 'invitation') NOT NULL DEFAULT 'application',
+  ADD COLUMN invitee_email varchar(255),
+  ADD COLUMN invited_by int,
+  ADD COLUMN expires_at datetime,
+  ADD INDEX idx_projectrequest_invitee_email (invitee_email),
+  ADD CONSTRAINT fk_projectrequest_invited_by FOREIGN KEY (invited_by) REFERENCES User (id);
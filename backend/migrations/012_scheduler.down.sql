@@ -0,0 +1,7 @@
+ALTER TABLE ProjectRequest
+  DROP INDEX idx_projectrequest_status_created;
+
+ALTER TABLE Notification
+  DROP INDEX idx_notification_user_type;
+
+DROP TABLE IF EXISTS SchedulerLease;
@@ -0,0 +1,13 @@
+-- one row per scheduled job; the replica holding an unexpired lease runs it
+CREATE TABLE SchedulerLease (
+    name varchar(100) PRIMARY KEY,
+    owner varchar(255) NOT NULL,
+    expires_at datetime(3) NOT NULL
+);
+
+-- reminders look up the last one sent to an owner for a project
+ALTER TABLE Notification
+  ADD INDEX idx_notification_user_type (user_id, type, created_at);
+
+ALTER TABLE ProjectRequest
+  ADD INDEX idx_projectrequest_status_created (status, created_at);
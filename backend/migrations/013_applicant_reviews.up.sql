@@ -0,0 +1,12 @@
+-- the owner's private review of a request, one row once it is reviewed
+CREATE TABLE ProjectRequestReview (
+    request_id int PRIMARY KEY,
+    shortlisted boolean NOT NULL DEFAULT false,
+    tags json,
+    rating tinyint,
+    note text,
+    reviewed_by int,
+    updated_at datetime DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    CONSTRAINT fk_projectrequestreview_request FOREIGN KEY (request_id) REFERENCES ProjectRequest (id) ON DELETE CASCADE,
+    CONSTRAINT fk_projectrequestreview_reviewed_by FOREIGN KEY (reviewed_by) REFERENCES User (id)
+);
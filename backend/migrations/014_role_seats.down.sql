@@ -0,0 +1,4 @@
+ALTER TABLE ProjectRole
+  DROP CHECK chk_projectrole_seats,
+  DROP COLUMN filled_seats,
+  DROP COLUMN seats;
@@ -0,0 +1,24 @@
+-- is_filled stays, kept equal to filled_seats >= seats, so existing filters
+-- keep working
+ALTER TABLE ProjectRole
+  ADD COLUMN seats int NOT NULL DEFAULT 1,
+  ADD COLUMN filled_seats int NOT NULL DEFAULT 0;
+
+UPDATE ProjectRole pr
+SET filled_seats = (
+  SELECT COUNT(*) FROM ProjectRequest r WHERE r.role_id = pr.id AND r.status = 'accepted'
+);
+
+-- roles with several accepted members get a seat for each; roles marked
+-- filled by hand stay full
+UPDATE ProjectRole
+SET seats = GREATEST(filled_seats, 1)
+WHERE is_filled = true OR filled_seats > 1;
+
+UPDATE ProjectRole SET filled_seats = seats WHERE is_filled = true;
+
+-- a single seat taken by an accepted member fills the role too
+UPDATE ProjectRole SET is_filled = (filled_seats >= seats);
+
+ALTER TABLE ProjectRole
+  ADD CONSTRAINT chk_projectrole_seats CHECK (seats >= 1 AND filled_seats >= 0 AND filled_seats <= seats);
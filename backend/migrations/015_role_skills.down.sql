@@ -0,0 +1,14 @@
+DROP TABLE IF EXISTS ProjectRoleSkill;
+
+ALTER TABLE ProjectRole
+  ADD COLUMN required_experience_level varchar(50);
+
+UPDATE ProjectRole
+SET required_experience_level = CASE experience_level
+  WHEN 'junior' THEN '1'
+  WHEN 'senior' THEN '5'
+  ELSE '3'
+END;
+
+ALTER TABLE ProjectRole
+  DROP COLUMN experience_level;
@@ -0,0 +1,28 @@
+-- levels 1-5 become junior (1-2), mid (3) and senior (4-5)
+ALTER TABLE ProjectRole
+  ADD COLUMN experience_level ENUM('junior', 'mid', 'senior') NOT NULL DEFAULT 'mid';
+
+UPDATE ProjectRole
+SET experience_level = CASE
+  WHEN required_experience_level IN ('1', '2') THEN 'junior'
+  WHEN required_experience_level IN ('4', '5') THEN 'senior'
+  ELSE 'mid'
+END;
+
+ALTER TABLE ProjectRole
+  DROP COLUMN required_experience_level;
+
+-- each row names one technology or one language a role asks for
+CREATE TABLE ProjectRoleSkill (
+    id int PRIMARY KEY AUTO_INCREMENT,
+    role_id int NOT NULL,
+    technology_id int,
+    language_id int,
+    required boolean NOT NULL DEFAULT true,
+    UNIQUE KEY uq_projectroleskill_technology (role_id, technology_id),
+    UNIQUE KEY uq_projectroleskill_language (role_id, language_id),
+    CONSTRAINT fk_projectroleskill_role FOREIGN KEY (role_id) REFERENCES ProjectRole (id) ON DELETE CASCADE,
+    CONSTRAINT fk_projectroleskill_technology FOREIGN KEY (technology_id) REFERENCES Technology (id),
+    CONSTRAINT fk_projectroleskill_language FOREIGN KEY (language_id) REFERENCES Language (id),
+    CONSTRAINT chk_projectroleskill_one CHECK ((technology_id IS NULL) <> (language_id IS NULL))
+);
@@ -0,0 +1,15 @@
+ALTER TABLE ProjectRequest
+  DROP INDEX idx_projectrequest_role_status;
+
+UPDATE ProjectRequest SET status = 'cancelled' WHERE status = 'waitlisted';
+
+ALTER TABLE ProjectRequest
+  MODIFY COLUMN status ENUM(
+    'pending',
+    'accepted',
+    'rejected',
+    'cancelled',
+    'withdrawn',
+    'removed',
+    'expired'
+  ) NOT NULL DEFAULT 'pending';
@@ -0,0 +1,15 @@
+ALTER TABLE ProjectRequest
+  MODIFY COLUMN status ENUM(
+    'pending',
+    'accepted',
+    'rejected',
+    'cancelled',
+    'withdrawn',
+    'removed',
+    'expired',
+    'waitlisted'
+  ) NOT NULL DEFAULT 'pending';
+
+-- the waitlist of a role is read in line order when a seat reopens
+ALTER TABLE ProjectRequest
+  ADD INDEX idx_projectrequest_role_status (role_id, status, id);
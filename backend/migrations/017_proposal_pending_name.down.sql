@@ -0,0 +1,5 @@
+ALTER TABLE TechnologyProposal
+  DROP INDEX pending_name_unique;
+
+ALTER TABLE TechnologyProposal
+  DROP COLUMN pending_name;
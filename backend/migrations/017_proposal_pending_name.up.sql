@@ -0,0 +1,24 @@
+-- pending_name holds the normalized name while a proposal is pending, so a
+-- unique key allows only one open proposal per technology; reviewed
+-- proposals clear it
+ALTER TABLE TechnologyProposal
+  ADD COLUMN pending_name varchar(255) NULL;
+
+-- mirrors domain.NormalizeTaxonomyName
+UPDATE TechnologyProposal
+SET pending_name = LOWER(REPLACE(REPLACE(REPLACE(REPLACE(TRIM(name), ' ', ''), '-', ''), '_', ''), '.', ''))
+WHERE status = 'pending';
+
+-- duplicates proposed before the key existed stay pending without a key,
+-- the oldest one keeps it
+UPDATE TechnologyProposal p
+JOIN (
+  SELECT pending_name, MIN(id) AS keep_id
+  FROM TechnologyProposal
+  WHERE pending_name IS NOT NULL
+  GROUP BY pending_name
+) k ON k.pending_name = p.pending_name AND p.id <> k.keep_id
+SET p.pending_name = NULL;
+
+ALTER TABLE TechnologyProposal
+  ADD UNIQUE KEY pending_name_unique (pending_name);
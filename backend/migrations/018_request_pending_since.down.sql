@@ -0,0 +1,6 @@
+ALTER TABLE ProjectRequest
+  DROP INDEX idx_projectrequest_status_pending,
+  ADD INDEX idx_projectrequest_status_created (status, created_at);
+
+ALTER TABLE ProjectRequest
+  DROP COLUMN pending_since;
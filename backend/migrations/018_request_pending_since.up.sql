@@ -0,0 +1,15 @@
+-- expiry and review reminders count from when a request became pending,
+-- which for a request promoted from the waitlist is not when it was made
+ALTER TABLE ProjectRequest
+  ADD COLUMN pending_since datetime NOT NULL DEFAULT CURRENT_TIMESTAMP;
+
+UPDATE ProjectRequest r
+SET pending_since = COALESCE(
+  (SELECT MAX(e.created_at) FROM ProjectRequestEvent e WHERE e.request_id = r.id AND e.to_status = 'pending'),
+  r.created_at,
+  r.pending_since
+);
+
+ALTER TABLE ProjectRequest
+  DROP INDEX idx_projectrequest_status_created,
+  ADD INDEX idx_projectrequest_status_pending (status, pending_since);
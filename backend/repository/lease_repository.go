@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// LeaseRepository stores scheduler leases in the SchedulerLease table.
+type LeaseRepository struct {
+	db *sqlx.DB
+}
+
+func NewLeaseRepository(db *sqlx.DB) *LeaseRepository {
+	return &LeaseRepository{db: db}
+}
+
+// Acquire takes the lease name for owner until now+ttl if it is free,
+// expired or already owner's. The upsert is atomic, so of several
+// replicas racing for an expired lease exactly one gets it.
+func (r *LeaseRepository) Acquire(ctx context.Context, name string, owner string, now time.Time, ttl time.Duration) (bool, error) {
+	// assignments run left to right: owner changes only if the lease has
+	// expired, and expires_at is extended only if owner is now ours
+	result, err := r.db.ExecContext(ctx, `
+		INSERT INTO SchedulerLease (name, owner, expires_at) VALUES (?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			owner = IF(expires_at <= ?, VALUES(owner), owner),
+			expires_at = IF(owner = VALUES(owner), VALUES(expires_at), expires_at)
+	`, name, owner, now.Add(ttl).UTC(), now.UTC())
+	if err != nil {
+		return false, err
+	}
+
+	// 1 for a new row, 2 for an updated one and 0 when someone else holds it
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
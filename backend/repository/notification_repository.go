@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/iemran93/devMatch/domain"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type NotificationRepository interface {
+	Create(ctx context.Context, n *domain.Notification) error
+}
+
+type notificationRepository struct {
+	db *sqlx.DB
+}
+
+func NewNotificationRepository(db *sqlx.DB) NotificationRepository {
+	return &notificationRepository{db: db}
+}
+
+func (r *notificationRepository) Create(ctx context.Context, n *domain.Notification) error {
+	result, err := r.db.NamedExecContext(ctx, `
+		INSERT INTO Notification (user_id, actor_id, project_id, type, content, is_read, created_at)
+		VALUES (:user_id, :actor_id, :project_id, :type, :content, :is_read, :created_at)
+	`, n)
+	if err != nil {
+		return err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	n.Id = int(id)
+	return nil
+}
@@ -2,6 +2,8 @@ package repository
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"github.com/iemran93/devMatch/domain"
 	"github.com/jmoiron/sqlx"
@@ -9,12 +11,32 @@ import (
 
 type ProjectActionsRepo interface {
 	List(ctx context.Context, id int) ([]*domain.ProjectRequest, error)
-	ApplyToProject(ctx context.Context, req domain.ProjectActionRequest) error
-	CancelRequestToProject(ctx context.Context, req domain.ProjectActionRequest) error
-	WithdrawFromProject(ctx context.Context, req domain.ProjectActionRequest) error
+	ApplyToProject(ctx context.Context, req domain.ProjectActionRequest, answers []domain.RequestAnswer, limits domain.ApplyLimits) (string, error)
+	TransitionRequest(ctx context.Context, requestId int, to string, actorId *int, note string) error
+	ReopenWaitlist(ctx context.Context, roleId int, promote bool) ([]domain.ProjectRequest, error)
+	ListRecentRejections(ctx context.Context, userId int, since time.Time) ([]domain.ApplyCooldown, error)
+	ReplyToRequest(ctx context.Context, req domain.ProjectActionReplyRequest, actorId int) (int64, error)
 	GetRequstsByUserId(ctx context.Context, userId int) ([]domain.ProjectRequest, error)
+	GetRequestById(ctx context.Context, requestId int) (*domain.ProjectRequest, error)
+	ListEvents(ctx context.Context, requestIds []int) ([]domain.ProjectRequestEvent, error)
+	ListAnswers(ctx context.Context, requestIds []int) ([]domain.RequestAnswer, error)
+	CreateInvitation(ctx context.Context, req *domain.InviteRequest, userId *int, invitedBy int, expiresAt time.Time) (int, error)
+	ListInvitations(ctx context.Context, userId int, email string) ([]*domain.ProjectRequest, error)
+	HasOpenRequest(ctx context.Context, roleId int, userId *int, email string) (bool, error)
+	AcceptInvitation(ctx context.Context, requestId int, userId int) (int64, error)
+	ListExpirable(ctx context.Context, pendingBefore time.Time, now time.Time, limit int) ([]domain.ExpirableRequest, error)
+	ListAwaitingReview(ctx context.Context, pendingBefore time.Time, remindedAfter time.Time) ([]domain.ReviewReminder, error)
+	ListApplicantProfiles(ctx context.Context, userIds []int) ([]domain.ApplicantProfile, error)
+	ListApplicantSkills(ctx context.Context, userIds []int) ([]domain.ApplicantSkill, error)
+	ListReviews(ctx context.Context, requestIds []int) ([]domain.ApplicantReview, error)
+	GetReview(ctx context.Context, requestId int) (*domain.ApplicantReview, error)
+	SaveReview(ctx context.Context, review *domain.ApplicantReview, reviewerId int) error
 }
 
+// roleFilledNote marks the rejections made when the last seat of a role is
+// taken; they say nothing about the applicant.
+const roleFilledNote = "role filled"
+
 type ProjectActionsRepository struct {
 	db *sqlx.DB
 }
@@ -26,7 +48,7 @@ func NewProjectActionsRepository(db *sqlx.DB) *ProjectActionsRepository {
 func (r *ProjectActionsRepository) List(ctx context.Context, id int) ([]*domain.ProjectRequest, error) {
 	projectRequests := make([]*domain.ProjectRequest, 0)
 
-	err := r.db.Select(&projectRequests, "SELECT * FROM ProjectRequest WHERE project_id = ?", id)
+	err := r.db.SelectContext(ctx, &projectRequests, "SELECT * FROM ProjectRequest WHERE project_id = ?", id)
 	if err != nil {
 		return nil, err
 	}
@@ -34,37 +56,183 @@ func (r *ProjectActionsRepository) List(ctx context.Context, id int) ([]*domain.
 	return projectRequests, nil
 }
 
-func (r *ProjectActionsRepository) ApplyToProject(ctx context.Context, req domain.ProjectActionRequest) error {
-	query := "INSERT INTO ProjectRequest (project_id, user_id, role_id, status) VALUES (?, ?, ?, ?)"
-	_, err := r.db.ExecContext(ctx, query, req.ProjectId, req.UserId, req.RoleId, req.Status)
+// ApplyToProject stores a request with its message, links and answers and
+// returns its status: pending, or waitlisted when the role has no seats
+// left. answers must already be checked against the role's questions. It
+// returns sql.ErrNoRows when the role is not part of the project, and
+// domain.ErrRequestAlreadyExists when the user already has an open request
+// for the role.
+//
+// Duplicates and the limits are checked under locks on the role and then
+// the user row, so concurrent applications cannot get past them.
+func (r *ProjectActionsRepository) ApplyToProject(ctx context.Context, req domain.ProjectActionRequest, answers []domain.RequestAnswer, limits domain.ApplyLimits) (string, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
 	if err != nil {
-		return err
+		return "", err
 	}
-	return nil
-}
-func (r *ProjectActionsRepository) CancelRequestToProject(ctx context.Context, req domain.ProjectActionRequest) error {
-	// delete from db
-	query := "DELETE FROM ProjectRequest WHERE project_id = ? AND user_id = ? AND role_id = ?"
-	_, err := r.db.ExecContext(ctx, query, req.ProjectId, req.UserId, req.RoleId)
-	return err
+	defer tx.Rollback()
+
+	// the role lock orders applications against replies filling the role
+	var full bool
+	err = tx.GetContext(ctx, &full, "SELECT filled_seats >= seats FROM ProjectRole WHERE id = ? AND project_id = ? FOR UPDATE",
+		req.RoleId, req.ProjectId)
+	if err != nil {
+		return "", err
+	}
+
+	// under the role lock a concurrent application of the same user is
+	// either committed or waits behind this one; an invitation sent to the
+	// user's email before they signed up counts too
+	var email string
+	if err := tx.GetContext(ctx, &email, "SELECT LOWER(email) FROM User WHERE id = ?", req.UserId); err != nil {
+		return "", err
+	}
+	exists, err := hasOpenRequest(ctx, tx, req.RoleId, &req.UserId, email)
+	if err != nil {
+		return "", err
+	}
+	if exists {
+		return "", domain.ErrRequestAlreadyExists
+	}
+
+	if err := checkApplyLimits(ctx, tx, req, limits); err != nil {
+		return "", err
+	}
+
+	status := domain.RequestPending
+	if full {
+		status = domain.RequestWaitlisted
+	}
+
+	var message *string
+	if req.Message != "" {
+		message = &req.Message
+	}
+
+	query := "INSERT INTO ProjectRequest (project_id, user_id, role_id, status, message, links) VALUES (?, ?, ?, ?, ?, ?)"
+	result, err := tx.ExecContext(ctx, query, req.ProjectId, req.UserId, req.RoleId, status,
+		message, domain.StringList(req.Links))
+	if err != nil {
+		return "", err
+	}
+	requestId, err := result.LastInsertId()
+	if err != nil {
+		return "", err
+	}
+
+	for _, a := range answers {
+		_, err = tx.ExecContext(ctx,
+			"INSERT INTO ProjectRequestAnswer (request_id, question_id, prompt, answer) VALUES (?, ?, ?, ?)",
+			requestId, a.QuestionId, a.Prompt, a.Answer)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	if err := insertRequestEvent(ctx, tx, int(requestId), &req.UserId, nil, status, ""); err != nil {
+		return "", err
+	}
+	return status, tx.Commit()
 }
-func (r *ProjectActionsRepository) WithdrawFromProject(ctx context.Context, req domain.ProjectActionRequest) error {
-	// delete from db
-	query := "DELETE FROM ProjectRequest WHERE project_id = ? AND user_id = ? AND role_id = ?"
-	_, err := r.db.ExecContext(ctx, query, req.ProjectId, req.UserId, req.RoleId)
+
+// TransitionRequest moves a request to status to and records the change.
+// Leaving accepted frees the member's seat. It fails with
+// domain.ErrInvalidTransition when the current status does not allow it.
+func (r *ProjectActionsRepository) TransitionRequest(ctx context.Context, requestId int, to string, actorId *int, note string) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
 	if err != nil {
 		return err
 	}
+	defer tx.Rollback()
 
-	// update project role to not filled
-	_, err = r.db.ExecContext(ctx,
-		"UPDATE ProjectRole SET is_filled = ? WHERE id = ?",
-		false, req.RoleId)
+	roleId, _, err := lockRequestRole(ctx, tx, requestId)
 	if err != nil {
 		return err
 	}
 
-	return nil
+	from, err := transitionRequest(ctx, tx, requestId, to, actorId, note)
+	if err != nil {
+		return err
+	}
+
+	if from == domain.RequestAccepted {
+		_, err = tx.ExecContext(ctx, `
+			UPDATE ProjectRole
+			SET filled_seats = GREATEST(filled_seats - 1, 0), is_filled = (filled_seats >= seats)
+			WHERE id = ?`, roleId)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// ReopenWaitlist hands the free seats of a role to its waitlist, in the
+// order the requests joined it. With promote the first requests, one per
+// free seat, move to pending and are returned; otherwise every waitlisted
+// request is returned unchanged. A role without free seats returns none.
+func (r *ProjectActionsRepository) ReopenWaitlist(ctx context.Context, roleId int, promote bool) ([]domain.ProjectRequest, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var free int
+	err = tx.GetContext(ctx, &free, "SELECT seats - filled_seats FROM ProjectRole WHERE id = ? FOR UPDATE", roleId)
+	if err != nil {
+		return nil, err
+	}
+	if free <= 0 {
+		return nil, nil
+	}
+
+	query := "SELECT * FROM ProjectRequest WHERE role_id = ? AND status = ? ORDER BY id"
+	args := []any{roleId, domain.RequestWaitlisted}
+	if promote {
+		query += " LIMIT ?"
+		args = append(args, free)
+	}
+	waitlist := make([]domain.ProjectRequest, 0)
+	if err := tx.SelectContext(ctx, &waitlist, query+" FOR UPDATE", args...); err != nil {
+		return nil, err
+	}
+
+	if promote {
+		for i := range waitlist {
+			if _, err := transitionRequest(ctx, tx, waitlist[i].Id, domain.RequestPending, nil, "seat reopened"); err != nil {
+				return nil, err
+			}
+			// the time to reply starts now, not when the request was made
+			_, err := tx.ExecContext(ctx, "UPDATE ProjectRequest SET pending_since = CURRENT_TIMESTAMP WHERE id = ?", waitlist[i].Id)
+			if err != nil {
+				return nil, err
+			}
+			waitlist[i].Status = domain.RequestPending
+		}
+	}
+	return waitlist, tx.Commit()
+}
+
+// ListRecentRejections returns, per role, the latest rejection of the user's
+// applications after since, newest first. Rejections made because the role
+// filled up are left out.
+func (r *ProjectActionsRepository) ListRecentRejections(ctx context.Context, userId int, since time.Time) ([]domain.ApplyCooldown, error) {
+	rejections := make([]domain.ApplyCooldown, 0)
+	err := r.db.SelectContext(ctx, &rejections, `
+		SELECT r.project_id, r.role_id, MAX(e.created_at) AS rejected_at
+		FROM ProjectRequestEvent e
+		JOIN ProjectRequest r ON r.id = e.request_id
+		WHERE r.user_id = ? AND r.direction = ? AND e.to_status = ?
+			AND (e.note IS NULL OR e.note <> ?) AND e.created_at > ?
+		GROUP BY r.project_id, r.role_id
+		ORDER BY rejected_at DESC
+	`, userId, domain.DirectionApplication, domain.RequestRejected, roleFilledNote, since)
+	if err != nil {
+		return nil, err
+	}
+	return rejections, nil
 }
 
 func (r *ProjectActionsRepository) GetRequstsByUserId(ctx context.Context, userId int) ([]domain.ProjectRequest, error) {
@@ -77,37 +245,134 @@ func (r *ProjectActionsRepository) GetRequstsByUserId(ctx context.Context, userI
 	return requests, nil
 }
 
-func (r *ProjectActionsRepository) ReplyToRequest(ctx context.Context, req domain.ProjectActionReplyRequest) error {
-	status := "rejected"
-	if req.Accepted {
-		status = "accepted"
+// ReplyToRequest accepts or rejects a pending request in one transaction.
+// The role row is locked first, so concurrent replies for the same role run
+// one after the other and no more are accepted than the role has seats.
+// Accepting the last seat also rejects the other pending requests for the
+// role; their count is returned.
+func (r *ProjectActionsRepository) ReplyToRequest(ctx context.Context, req domain.ProjectActionReplyRequest, actorId int) (int64, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, err
 	}
+	defer tx.Rollback()
 
-	_, err := r.db.ExecContext(ctx,
-		"UPDATE ProjectRequest SET status = ? WHERE id = ?",
-		status, req.RequestId)
+	roleId, full, err := lockRequestRole(ctx, tx, req.RequestId)
 	if err != nil {
-		return err
+		return 0, err
+	}
+	if req.Accepted && full {
+		return 0, domain.ErrRequestNorAllowed.WithMessage("role has no seats left")
 	}
 
-	// if accepted update project role to fill
-	if status == "accepted" {
-		var roleId int
-		err := r.db.QueryRowContext(ctx,
-			"SELECT role_id FROM ProjectRequest WHERE id = ?",
-			req.RequestId).Scan(&roleId)
-		if err != nil {
-			return err
+	if !req.Accepted {
+		if _, err := transitionRequest(ctx, tx, req.RequestId, domain.RequestRejected, &actorId, ""); err != nil {
+			return 0, err
 		}
+		return 0, tx.Commit()
+	}
 
-		_, err = r.db.ExecContext(ctx,
-			"UPDATE ProjectRole SET is_filled = ? WHERE id = ?",
-			true, roleId)
-		if err != nil {
-			return err
-		}
+	autoRejected, err := acceptRequest(ctx, tx, req.RequestId, roleId, actorId)
+	if err != nil {
+		return 0, err
 	}
-	return nil
+	return autoRejected, tx.Commit()
+}
+
+// CreateInvitation stores a pending invitation. userId is nil when the
+// invitee has no account yet and is only known by email.
+func (r *ProjectActionsRepository) CreateInvitation(ctx context.Context, req *domain.InviteRequest, userId *int, invitedBy int, expiresAt time.Time) (int, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	var email, message *string
+	if req.Email != "" {
+		email = &req.Email
+	}
+	if req.Message != "" {
+		message = &req.Message
+	}
+
+	result, err := tx.ExecContext(ctx, `
+		INSERT INTO ProjectRequest (project_id, user_id, role_id, status, message, direction, invitee_email, invited_by, expires_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, req.ProjectId, userId, req.RoleId, domain.RequestPending, message,
+		domain.DirectionInvitation, email, invitedBy, expiresAt)
+	if err != nil {
+		return 0, err
+	}
+	requestId, err := result.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+
+	if err := insertRequestEvent(ctx, tx, int(requestId), &invitedBy, nil, domain.RequestPending, "invited"); err != nil {
+		return 0, err
+	}
+	return int(requestId), tx.Commit()
+}
+
+// ListInvitations returns the invitations addressed to a user, by id or by
+// the email they were invited with before signing up.
+func (r *ProjectActionsRepository) ListInvitations(ctx context.Context, userId int, email string) ([]*domain.ProjectRequest, error) {
+	invitations := make([]*domain.ProjectRequest, 0)
+	err := r.db.SelectContext(ctx, &invitations, `
+		SELECT * FROM ProjectRequest
+		WHERE direction = ? AND (user_id = ? OR (user_id IS NULL AND invitee_email = ?))
+		ORDER BY id DESC
+	`, domain.DirectionInvitation, userId, email)
+	if err != nil {
+		return nil, err
+	}
+	return invitations, nil
+}
+
+// HasOpenRequest reports whether the user, or the email, already has a
+// pending, waitlisted or accepted request for the role in either direction.
+func (r *ProjectActionsRepository) HasOpenRequest(ctx context.Context, roleId int, userId *int, email string) (bool, error) {
+	return hasOpenRequest(ctx, r.db, roleId, userId, email)
+}
+
+func hasOpenRequest(ctx context.Context, q sqlx.QueryerContext, roleId int, userId *int, email string) (bool, error) {
+	var count int
+	err := sqlx.GetContext(ctx, q, &count, `
+		SELECT COUNT(*) FROM ProjectRequest
+		WHERE role_id = ? AND status IN (?, ?, ?) AND (user_id = ? OR invitee_email = ?)
+	`, roleId, domain.RequestPending, domain.RequestWaitlisted, domain.RequestAccepted, userId, email)
+	return count > 0, err
+}
+
+// AcceptInvitation accepts a pending invitation for userId and takes a seat
+// of the role, like ReplyToRequest.
+func (r *ProjectActionsRepository) AcceptInvitation(ctx context.Context, requestId int, userId int) (int64, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	roleId, full, err := lockRequestRole(ctx, tx, requestId)
+	if err != nil {
+		return 0, err
+	}
+	if full {
+		return 0, domain.ErrRequestNorAllowed.WithMessage("role has no seats left")
+	}
+
+	// email invitations are bound to the account that accepts them
+	_, err = tx.ExecContext(ctx, "UPDATE ProjectRequest SET user_id = ? WHERE id = ?", userId, requestId)
+	if err != nil {
+		return 0, err
+	}
+
+	autoRejected, err := acceptRequest(ctx, tx, requestId, roleId, userId)
+	if err != nil {
+		return 0, err
+	}
+	return autoRejected, tx.Commit()
 }
 
 func (r *ProjectActionsRepository) GetRequestById(ctx context.Context, requestId int) (*domain.ProjectRequest, error) {
@@ -119,3 +384,306 @@ func (r *ProjectActionsRepository) GetRequestById(ctx context.Context, requestId
 	}
 	return &request, nil
 }
+
+// ListExpirable returns up to limit pending requests that should expire:
+// applications pending since before pendingBefore, unless it is zero, and
+// invitations past their expiry at now.
+func (r *ProjectActionsRepository) ListExpirable(ctx context.Context, pendingBefore time.Time, now time.Time, limit int) ([]domain.ExpirableRequest, error) {
+	query := `
+		SELECT r.*, pr.title AS role_title, p.title AS project_title
+		FROM ProjectRequest r
+		JOIN ProjectRole pr ON pr.id = r.role_id
+		JOIN Project p ON p.id = r.project_id
+		WHERE r.status = ? AND ((r.direction = ? AND r.expires_at <= ?)`
+	args := []any{domain.RequestPending, domain.DirectionInvitation, now}
+	if !pendingBefore.IsZero() {
+		query += " OR (r.direction = ? AND r.pending_since <= ?)"
+		args = append(args, domain.DirectionApplication, pendingBefore)
+	}
+	query += ") ORDER BY r.id LIMIT ?"
+	args = append(args, limit)
+
+	requests := make([]domain.ExpirableRequest, 0)
+	if err := r.db.SelectContext(ctx, &requests, query, args...); err != nil {
+		return nil, err
+	}
+	return requests, nil
+}
+
+// ListAwaitingReview returns the projects with applications pending since
+// before pendingBefore whose owner has not been reminded since
+// remindedAfter.
+func (r *ProjectActionsRepository) ListAwaitingReview(ctx context.Context, pendingBefore time.Time, remindedAfter time.Time) ([]domain.ReviewReminder, error) {
+	reminders := make([]domain.ReviewReminder, 0)
+	err := r.db.SelectContext(ctx, &reminders, `
+		SELECT p.id AS project_id, p.creator_id AS owner_id, COUNT(*) AS pending
+		FROM ProjectRequest r
+		JOIN Project p ON p.id = r.project_id
+		WHERE r.status = ? AND r.direction = ? AND r.pending_since <= ?
+			AND NOT EXISTS (
+				SELECT 1 FROM Notification n
+				WHERE n.user_id = p.creator_id AND n.type = ? AND n.project_id = p.id AND n.created_at > ?
+			)
+		GROUP BY p.id, p.creator_id
+	`, domain.RequestPending, domain.DirectionApplication, pendingBefore,
+		domain.NotificationRequestReminder, remindedAfter)
+	if err != nil {
+		return nil, err
+	}
+	return reminders, nil
+}
+
+// ListEvents returns the history of the given requests, oldest first.
+func (r *ProjectActionsRepository) ListEvents(ctx context.Context, requestIds []int) ([]domain.ProjectRequestEvent, error) {
+	events := make([]domain.ProjectRequestEvent, 0)
+	if len(requestIds) == 0 {
+		return events, nil
+	}
+
+	query, args, err := sqlx.In("SELECT * FROM ProjectRequestEvent WHERE request_id IN (?) ORDER BY id", requestIds)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.db.SelectContext(ctx, &events, r.db.Rebind(query), args...); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// ListAnswers returns the answers of the given requests.
+func (r *ProjectActionsRepository) ListAnswers(ctx context.Context, requestIds []int) ([]domain.RequestAnswer, error) {
+	answers := make([]domain.RequestAnswer, 0)
+	if len(requestIds) == 0 {
+		return answers, nil
+	}
+
+	query, args, err := sqlx.In("SELECT * FROM ProjectRequestAnswer WHERE request_id IN (?) ORDER BY id", requestIds)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.db.SelectContext(ctx, &answers, r.db.Rebind(query), args...); err != nil {
+		return nil, err
+	}
+	return answers, nil
+}
+
+// ListApplicantProfiles returns the public profile of the given users.
+func (r *ProjectActionsRepository) ListApplicantProfiles(ctx context.Context, userIds []int) ([]domain.ApplicantProfile, error) {
+	profiles := make([]domain.ApplicantProfile, 0)
+	if len(userIds) == 0 {
+		return profiles, nil
+	}
+
+	query, args, err := sqlx.In("SELECT id, name, email, profile_picture, availability FROM User WHERE id IN (?)", userIds)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.db.SelectContext(ctx, &profiles, r.db.Rebind(query), args...); err != nil {
+		return nil, err
+	}
+	return profiles, nil
+}
+
+// ListApplicantSkills returns the skills of the given users.
+func (r *ProjectActionsRepository) ListApplicantSkills(ctx context.Context, userIds []int) ([]domain.ApplicantSkill, error) {
+	skills := make([]domain.ApplicantSkill, 0)
+	if len(userIds) == 0 {
+		return skills, nil
+	}
+
+	query, args, err := sqlx.In(`
+		SELECT us.user_id, us.category_id, us.technology_id, t.name AS technology,
+			us.language_id, l.name AS language, us.proficiency_level
+		FROM UserSkill us
+		LEFT JOIN Technology t ON t.id = us.technology_id
+		LEFT JOIN Language l ON l.id = us.language_id
+		WHERE us.user_id IN (?)
+		ORDER BY us.id
+	`, userIds)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.db.SelectContext(ctx, &skills, r.db.Rebind(query), args...); err != nil {
+		return nil, err
+	}
+	return skills, nil
+}
+
+// ListReviews returns the reviews of the given requests. Requests that were
+// never reviewed have none.
+func (r *ProjectActionsRepository) ListReviews(ctx context.Context, requestIds []int) ([]domain.ApplicantReview, error) {
+	reviews := make([]domain.ApplicantReview, 0)
+	if len(requestIds) == 0 {
+		return reviews, nil
+	}
+
+	query, args, err := sqlx.In(`
+		SELECT request_id, shortlisted, tags, rating, note, updated_at
+		FROM ProjectRequestReview WHERE request_id IN (?)
+	`, requestIds)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.db.SelectContext(ctx, &reviews, r.db.Rebind(query), args...); err != nil {
+		return nil, err
+	}
+	return reviews, nil
+}
+
+// GetReview returns the review of a request, or sql.ErrNoRows.
+func (r *ProjectActionsRepository) GetReview(ctx context.Context, requestId int) (*domain.ApplicantReview, error) {
+	var review domain.ApplicantReview
+	err := r.db.GetContext(ctx, &review, `
+		SELECT request_id, shortlisted, tags, rating, note, updated_at
+		FROM ProjectRequestReview WHERE request_id = ?
+	`, requestId)
+	if err != nil {
+		return nil, err
+	}
+	return &review, nil
+}
+
+// SaveReview creates or replaces the review of review.RequestId.
+func (r *ProjectActionsRepository) SaveReview(ctx context.Context, review *domain.ApplicantReview, reviewerId int) error {
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO ProjectRequestReview (request_id, shortlisted, tags, rating, note, reviewed_by)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			shortlisted = VALUES(shortlisted),
+			tags = VALUES(tags),
+			rating = VALUES(rating),
+			note = VALUES(note),
+			reviewed_by = VALUES(reviewed_by)
+	`, review.RequestId, review.Shortlisted, review.Tags, review.Rating, review.Note, reviewerId)
+	return err
+}
+
+// checkApplyLimits counts the open applications of the role, whose row the
+// caller has locked, and of the user, whose row it locks, against limits.
+// The user lock comes after the role lock like every other request write.
+func checkApplyLimits(ctx context.Context, tx *sqlx.Tx, req domain.ProjectActionRequest, limits domain.ApplyLimits) error {
+	const openApplications = "SELECT COUNT(*) FROM ProjectRequest WHERE %s = ? AND direction = ? AND status IN (?, ?)"
+
+	if limits.MaxOpenPerRole > 0 {
+		var open int
+		err := tx.GetContext(ctx, &open, fmt.Sprintf(openApplications, "role_id"),
+			req.RoleId, domain.DirectionApplication, domain.RequestPending, domain.RequestWaitlisted)
+		if err != nil {
+			return err
+		}
+		if open >= limits.MaxOpenPerRole {
+			return domain.ErrRoleApplicationLimit.WithMessage(
+				fmt.Sprintf("the role already has %d open applications, try again later", open))
+		}
+	}
+
+	if limits.MaxOpenPerUser > 0 {
+		var userId int
+		if err := tx.GetContext(ctx, &userId, "SELECT id FROM User WHERE id = ? FOR UPDATE", req.UserId); err != nil {
+			return err
+		}
+		var open int
+		err := tx.GetContext(ctx, &open, fmt.Sprintf(openApplications, "user_id"),
+			req.UserId, domain.DirectionApplication, domain.RequestPending, domain.RequestWaitlisted)
+		if err != nil {
+			return err
+		}
+		if open >= limits.MaxOpenPerUser {
+			return domain.ErrApplicationLimit.WithMessage(
+				fmt.Sprintf("you have %d open applications, the limit is %d; cancel one or wait for a reply", open, limits.MaxOpenPerUser))
+		}
+	}
+	return nil
+}
+
+// lockRequestRole locks the role a request is for and reports whether all
+// its seats are filled. Every write that may touch both rows takes the role
+// lock before the request lock, so they cannot deadlock.
+func lockRequestRole(ctx context.Context, tx *sqlx.Tx, requestId int) (roleId int, full bool, err error) {
+	// role_id never changes, so it can be read before taking the locks
+	err = tx.GetContext(ctx, &roleId, "SELECT role_id FROM ProjectRequest WHERE id = ?", requestId)
+	if err != nil {
+		return 0, false, err
+	}
+
+	err = tx.GetContext(ctx, &full, "SELECT filled_seats >= seats FROM ProjectRole WHERE id = ? FOR UPDATE", roleId)
+	if err != nil {
+		return 0, false, err
+	}
+	return roleId, full, nil
+}
+
+// acceptRequest accepts a request whose role is locked and has a free
+// seat, and takes the seat. When that was the last one the other pending
+// requests for the role are rejected. It returns how many were rejected.
+func acceptRequest(ctx context.Context, tx *sqlx.Tx, requestId int, roleId int, actorId int) (int64, error) {
+	if _, err := transitionRequest(ctx, tx, requestId, domain.RequestAccepted, &actorId, ""); err != nil {
+		return 0, err
+	}
+
+	_, err := tx.ExecContext(ctx, `
+		UPDATE ProjectRole
+		SET filled_seats = filled_seats + 1, is_filled = (filled_seats >= seats)
+		WHERE id = ?`, roleId)
+	if err != nil {
+		return 0, err
+	}
+
+	var full bool
+	if err := tx.GetContext(ctx, &full, "SELECT is_filled FROM ProjectRole WHERE id = ?", roleId); err != nil {
+		return 0, err
+	}
+	if !full {
+		return 0, nil
+	}
+
+	var pending []int
+	err = tx.SelectContext(ctx, &pending,
+		"SELECT id FROM ProjectRequest WHERE role_id = ? AND status = ?",
+		roleId, domain.RequestPending)
+	if err != nil {
+		return 0, err
+	}
+	for _, id := range pending {
+		if _, err := transitionRequest(ctx, tx, id, domain.RequestRejected, &actorId, roleFilledNote); err != nil {
+			return 0, err
+		}
+	}
+	return int64(len(pending)), nil
+}
+
+// transitionRequest changes the status of a request and records the event.
+// It returns the previous status.
+func transitionRequest(ctx context.Context, tx *sqlx.Tx, requestId int, to string, actorId *int, note string) (string, error) {
+	var from string
+	err := tx.GetContext(ctx, &from, "SELECT status FROM ProjectRequest WHERE id = ? FOR UPDATE", requestId)
+	if err != nil {
+		return "", err
+	}
+	if !domain.CanTransitionRequest(from, to) {
+		return "", domain.ErrInvalidTransition.WithMessage(fmt.Sprintf("cannot change a %s request to %s", from, to))
+	}
+
+	_, err = tx.ExecContext(ctx, "UPDATE ProjectRequest SET status = ? WHERE id = ?", to, requestId)
+	if err != nil {
+		return "", err
+	}
+
+	if err := insertRequestEvent(ctx, tx, requestId, actorId, &from, to, note); err != nil {
+		return "", err
+	}
+	return from, nil
+}
+
+func insertRequestEvent(ctx context.Context, tx *sqlx.Tx, requestId int, actorId *int, from *string, to string, note string) error {
+	var notePtr *string
+	if note != "" {
+		notePtr = &note
+	}
+
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO ProjectRequestEvent (request_id, actor_id, from_status, to_status, note)
+		VALUES (?, ?, ?, ?, ?)
+	`, requestId, actorId, from, to, notePtr)
+	return err
+}
@@ -0,0 +1,474 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	_ "github.com/go-sql-driver/mysql"
+	"github.com/iemran93/devMatch/domain"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testDB connects to the migrated MySQL database in TEST_DB_DSN, e.g.
+// "user:pass@(localhost:3306)/devmatch_test?parseTime=true". Tests that need
+// it are skipped when it is not set.
+func testDB(t *testing.T) *sqlx.DB {
+	dsn := os.Getenv("TEST_DB_DSN")
+	if dsn == "" {
+		t.Skip("TEST_DB_DSN not set")
+	}
+
+	db, err := sqlx.Connect("mysql", dsn)
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+// fixture is a project with one open role, removed again after the test.
+type fixture struct {
+	db        *sqlx.DB
+	ownerId   int
+	projectId int
+	roleId    int
+	userIds   []int
+}
+
+func newFixture(t *testing.T, db *sqlx.DB) *fixture {
+	f := &fixture{db: db}
+	suffix := time.Now().UnixNano()
+
+	f.ownerId = f.exec(t, "INSERT INTO User (name, email, password) VALUES (?, ?, '')", "owner", fmt.Sprintf("owner-%d@example.com", suffix))
+	categoryId := f.exec(t, "INSERT INTO Category (name) VALUES (?)", fmt.Sprintf("category-%d", suffix))
+	f.projectId = f.exec(t, `INSERT INTO Project (title, description, category_id, stage, created_at, updated_at, creator_id)
+		VALUES ('race', 'race', ?, 'Idea', NOW(), NOW(), ?)`, categoryId, f.ownerId)
+	f.roleId = f.exec(t, "INSERT INTO ProjectRole (project_id, title, description, experience_level, is_filled) VALUES (?, 'backend', '', 'junior', false)", f.projectId)
+
+	t.Cleanup(func() {
+		db.Exec("DELETE FROM ProjectRequest WHERE project_id = ?", f.projectId)
+		db.Exec("DELETE FROM ProjectRole WHERE id = ?", f.roleId)
+		db.Exec("DELETE FROM Project WHERE id = ?", f.projectId)
+		db.Exec("DELETE FROM Category WHERE id = ?", categoryId)
+		for _, id := range append(f.userIds, f.ownerId) {
+			db.Exec("DELETE FROM User WHERE id = ?", id)
+		}
+	})
+	return f
+}
+
+func (f *fixture) exec(t *testing.T, query string, args ...any) int {
+	result, err := f.db.Exec(query, args...)
+	require.NoError(t, err)
+	id, err := result.LastInsertId()
+	require.NoError(t, err)
+	return int(id)
+}
+
+// apply adds an applicant with a pending request for the role.
+func (f *fixture) apply(t *testing.T) (userId int, requestId int) {
+	userId = f.exec(t, "INSERT INTO User (name, email, password) VALUES (?, ?, '')", "applicant",
+		fmt.Sprintf("applicant-%d-%d@example.com", len(f.userIds), time.Now().UnixNano()))
+	f.userIds = append(f.userIds, userId)
+	requestId = f.exec(t, "INSERT INTO ProjectRequest (project_id, user_id, role_id) VALUES (?, ?, ?)", f.projectId, userId, f.roleId)
+	return userId, requestId
+}
+
+func TestReplyToRequest_ConcurrentAccepts(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+	f := newFixture(t, db)
+	ownerId, roleId := f.ownerId, f.roleId
+
+	const applicants = 5
+	var requestIds []int
+	for i := 0; i < applicants; i++ {
+		_, requestId := f.apply(t)
+		requestIds = append(requestIds, requestId)
+	}
+
+	repo := NewProjectActionsRepository(db)
+	errs := make([]error, applicants)
+	var wg sync.WaitGroup
+	for i, requestId := range requestIds {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, errs[i] = repo.ReplyToRequest(ctx, domain.ProjectActionReplyRequest{RequestId: requestId, Accepted: true}, ownerId)
+		}()
+	}
+	wg.Wait()
+
+	wins := 0
+	for _, err := range errs {
+		if err == nil {
+			wins++
+		} else {
+			assert.ErrorIs(t, err, domain.ErrRequestNorAllowed)
+		}
+	}
+	assert.Equal(t, 1, wins)
+
+	var accepted, rejected int
+	require.NoError(t, db.GetContext(ctx, &accepted, "SELECT COUNT(*) FROM ProjectRequest WHERE role_id = ? AND status = 'accepted'", roleId))
+	require.NoError(t, db.GetContext(ctx, &rejected, "SELECT COUNT(*) FROM ProjectRequest WHERE role_id = ? AND status = 'rejected'", roleId))
+	assert.Equal(t, 1, accepted)
+	assert.Equal(t, applicants-1, rejected)
+
+	var isFilled bool
+	require.NoError(t, db.GetContext(ctx, &isFilled, "SELECT is_filled FROM ProjectRole WHERE id = ?", roleId))
+	assert.True(t, isFilled)
+
+	// every request has its reply in the history
+	events, err := repo.ListEvents(ctx, requestIds)
+	require.NoError(t, err)
+	assert.Len(t, events, applicants)
+}
+
+func TestTransitionRequest_RemoveReopensRole(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+	f := newFixture(t, db)
+	_, requestId := f.apply(t)
+
+	repo := NewProjectActionsRepository(db)
+	_, err := repo.ReplyToRequest(ctx, domain.ProjectActionReplyRequest{RequestId: requestId, Accepted: true}, f.ownerId)
+	require.NoError(t, err)
+
+	require.NoError(t, repo.TransitionRequest(ctx, requestId, domain.RequestRemoved, &f.ownerId, "inactive"))
+
+	var isFilled bool
+	require.NoError(t, db.GetContext(ctx, &isFilled, "SELECT is_filled FROM ProjectRole WHERE id = ?", f.roleId))
+	assert.False(t, isFilled)
+
+	events, err := repo.ListEvents(ctx, []int{requestId})
+	require.NoError(t, err)
+	last := events[len(events)-1]
+	assert.Equal(t, domain.RequestRemoved, last.ToStatus)
+	assert.Equal(t, "inactive", *last.Note)
+
+	// removed is final
+	assert.ErrorIs(t, repo.TransitionRequest(ctx, requestId, domain.RequestRemoved, &f.ownerId, ""), domain.ErrInvalidTransition)
+}
+
+func TestReplyToRequest_FillsSeats(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+	f := newFixture(t, db)
+	_, err := db.Exec("UPDATE ProjectRole SET seats = 2 WHERE id = ?", f.roleId)
+	require.NoError(t, err)
+
+	var requestIds []int
+	for i := 0; i < 3; i++ {
+		_, requestId := f.apply(t)
+		requestIds = append(requestIds, requestId)
+	}
+
+	repo := NewProjectActionsRepository(db)
+	rolesRepo := NewProjectRolesRepository(db)
+	accept := func(requestId int) (int64, error) {
+		return repo.ReplyToRequest(ctx, domain.ProjectActionReplyRequest{RequestId: requestId, Accepted: true}, f.ownerId)
+	}
+
+	// the first seat leaves the other requests pending
+	autoRejected, err := accept(requestIds[0])
+	require.NoError(t, err)
+	assert.Zero(t, autoRejected)
+	role, err := rolesRepo.Get(ctx, f.roleId)
+	require.NoError(t, err)
+	assert.Equal(t, 1, role.SeatsRemaining)
+	assert.False(t, role.IsFilled)
+
+	// the last seat rejects the rest
+	autoRejected, err = accept(requestIds[1])
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, autoRejected)
+	role, err = rolesRepo.Get(ctx, f.roleId)
+	require.NoError(t, err)
+	assert.Equal(t, 0, role.SeatsRemaining)
+	assert.True(t, role.IsFilled)
+
+	// a member leaving frees their seat
+	require.NoError(t, repo.TransitionRequest(ctx, requestIds[0], domain.RequestWithdrawn, nil, ""))
+	role, err = rolesRepo.Get(ctx, f.roleId)
+	require.NoError(t, err)
+	assert.Equal(t, 1, role.FilledSeats)
+	assert.False(t, role.IsFilled)
+
+	// seats taken and freed are not owner edits, the role keeps its version
+	assert.Equal(t, 1, role.Version)
+}
+
+func TestApplyToProject_Waitlist(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+	f := newFixture(t, db)
+	_, memberRequest := f.apply(t)
+
+	repo := NewProjectActionsRepository(db)
+	_, err := repo.ReplyToRequest(ctx, domain.ProjectActionReplyRequest{RequestId: memberRequest, Accepted: true}, f.ownerId)
+	require.NoError(t, err)
+
+	// applying to the filled role joins its waitlist
+	var waitlisted []int
+	for i := 0; i < 2; i++ {
+		userId := f.exec(t, "INSERT INTO User (name, email, password) VALUES (?, ?, '')", "waiting",
+			fmt.Sprintf("waiting-%d-%d@example.com", i, time.Now().UnixNano()))
+		f.userIds = append(f.userIds, userId)
+		status, err := repo.ApplyToProject(ctx, domain.ProjectActionRequest{ProjectId: f.projectId, RoleId: f.roleId, UserId: userId}, nil, domain.ApplyLimits{})
+		require.NoError(t, err)
+		assert.Equal(t, domain.RequestWaitlisted, status)
+		waitlisted = append(waitlisted, userId)
+	}
+
+	// no seat is free yet
+	promoted, err := repo.ReopenWaitlist(ctx, f.roleId, true)
+	require.NoError(t, err)
+	assert.Empty(t, promoted)
+
+	require.NoError(t, repo.TransitionRequest(ctx, memberRequest, domain.RequestWithdrawn, nil, ""))
+
+	// without promoting the whole line is returned in order
+	waiting, err := repo.ReopenWaitlist(ctx, f.roleId, false)
+	require.NoError(t, err)
+	require.Len(t, waiting, 2)
+	assert.Equal(t, waitlisted[0], *waiting[0].UserId)
+	assert.Equal(t, waitlisted[1], *waiting[1].UserId)
+
+	// promoting moves one request per free seat, first in line first
+	promoted, err = repo.ReopenWaitlist(ctx, f.roleId, true)
+	require.NoError(t, err)
+	require.Len(t, promoted, 1)
+	assert.Equal(t, waitlisted[0], *promoted[0].UserId)
+
+	var status string
+	require.NoError(t, db.GetContext(ctx, &status, "SELECT status FROM ProjectRequest WHERE id = ?", promoted[0].Id))
+	assert.Equal(t, domain.RequestPending, status)
+}
+
+func TestReopenWaitlist_PromotedStartsPending(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+	f := newFixture(t, db)
+	_, memberRequest := f.apply(t)
+
+	repo := NewProjectActionsRepository(db)
+	_, err := repo.ReplyToRequest(ctx, domain.ProjectActionReplyRequest{RequestId: memberRequest, Accepted: true}, f.ownerId)
+	require.NoError(t, err)
+
+	userId := f.exec(t, "INSERT INTO User (name, email, password) VALUES (?, ?, '')", "waiting",
+		fmt.Sprintf("waiting-%d@example.com", time.Now().UnixNano()))
+	f.userIds = append(f.userIds, userId)
+	_, err = repo.ApplyToProject(ctx, domain.ProjectActionRequest{ProjectId: f.projectId, RoleId: f.roleId, UserId: userId}, nil, domain.ApplyLimits{})
+	require.NoError(t, err)
+
+	// the request waited in line for two months
+	var requestId int
+	require.NoError(t, db.GetContext(ctx, &requestId, "SELECT id FROM ProjectRequest WHERE user_id = ? AND role_id = ?", userId, f.roleId))
+	f.exec(t, "UPDATE ProjectRequest SET created_at = NOW() - INTERVAL 60 DAY, pending_since = NOW() - INTERVAL 60 DAY WHERE id = ?", requestId)
+
+	require.NoError(t, repo.TransitionRequest(ctx, memberRequest, domain.RequestWithdrawn, nil, ""))
+	promoted, err := repo.ReopenWaitlist(ctx, f.roleId, true)
+	require.NoError(t, err)
+	require.Len(t, promoted, 1)
+
+	// the owner gets the full time to reply from the promotion on
+	now := time.Now()
+	expirable, err := repo.ListExpirable(ctx, now.Add(-30*24*time.Hour), now, 1000)
+	require.NoError(t, err)
+	for _, r := range expirable {
+		assert.NotEqual(t, requestId, r.Id)
+	}
+	reminders, err := repo.ListAwaitingReview(ctx, now.Add(-48*time.Hour), now.Add(-24*time.Hour))
+	require.NoError(t, err)
+	for _, r := range reminders {
+		assert.NotEqual(t, f.projectId, r.ProjectId)
+	}
+
+	// and it expires once that runs out
+	f.exec(t, "UPDATE ProjectRequest SET pending_since = NOW() - INTERVAL 31 DAY WHERE id = ?", requestId)
+	expirable, err = repo.ListExpirable(ctx, now.Add(-30*24*time.Hour), now, 1000)
+	require.NoError(t, err)
+	var ids []int
+	for _, r := range expirable {
+		ids = append(ids, r.Id)
+	}
+	assert.Contains(t, ids, requestId)
+}
+
+func TestListRecentRejections_SkipsRoleFilled(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+	f := newFixture(t, db)
+	_, member := f.apply(t)
+	rejectedUser, rejected := f.apply(t)
+	crowdedOutUser, _ := f.apply(t)
+
+	repo := NewProjectActionsRepository(db)
+	_, err := repo.ReplyToRequest(ctx, domain.ProjectActionReplyRequest{RequestId: rejected, Accepted: false}, f.ownerId)
+	require.NoError(t, err)
+	// taking the only seat rejects the remaining request as "role filled"
+	_, err = repo.ReplyToRequest(ctx, domain.ProjectActionReplyRequest{RequestId: member, Accepted: true}, f.ownerId)
+	require.NoError(t, err)
+
+	since := time.Now().Add(-time.Hour)
+	rejections, err := repo.ListRecentRejections(ctx, rejectedUser, since)
+	require.NoError(t, err)
+	require.Len(t, rejections, 1)
+	assert.Equal(t, f.roleId, rejections[0].RoleId)
+
+	rejections, err = repo.ListRecentRejections(ctx, crowdedOutUser, since)
+	require.NoError(t, err)
+	assert.Empty(t, rejections)
+}
+
+func TestDelete_WithRequestHistory(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+	suffix := time.Now().UnixNano()
+
+	exec := func(query string, args ...any) int {
+		result, err := db.ExecContext(ctx, query, args...)
+		require.NoError(t, err)
+		id, err := result.LastInsertId()
+		require.NoError(t, err)
+		return int(id)
+	}
+
+	ownerId := exec("INSERT INTO User (name, email, password) VALUES (?, ?, '')", "owner", fmt.Sprintf("owner-%d@example.com", suffix))
+	userId := exec("INSERT INTO User (name, email, password) VALUES (?, ?, '')", "applicant", fmt.Sprintf("applicant-%d@example.com", suffix))
+	categoryId := exec("INSERT INTO Category (name) VALUES (?)", fmt.Sprintf("category-%d", suffix))
+	t.Cleanup(func() {
+		db.Exec("DELETE FROM Category WHERE id = ?", categoryId)
+		db.Exec("DELETE FROM User WHERE id IN (?, ?)", ownerId, userId)
+	})
+
+	repo := NewProjectActionsRepository(db)
+	// finished requests stay as history and must not block deletes
+	cancelled := func() (projectId, roleId, requestId int) {
+		projectId = exec(`INSERT INTO Project (title, description, category_id, stage, created_at, updated_at, creator_id)
+			VALUES ('history', 'history', ?, 'Idea', NOW(), NOW(), ?)`, categoryId, ownerId)
+		roleId = exec("INSERT INTO ProjectRole (project_id, title, description, required_experience_level, is_filled) VALUES (?, 'backend', '', 1, false)", projectId)
+		requestId = exec("INSERT INTO ProjectRequest (project_id, user_id, role_id) VALUES (?, ?, ?)", projectId, userId, roleId)
+		require.NoError(t, repo.TransitionRequest(ctx, requestId, domain.RequestCancelled, &userId, ""))
+		t.Cleanup(func() {
+			db.Exec("DELETE FROM ProjectRequest WHERE project_id = ?", projectId)
+			db.Exec("DELETE FROM ProjectRole WHERE project_id = ?", projectId)
+			db.Exec("DELETE FROM Project WHERE id = ?", projectId)
+		})
+		return projectId, roleId, requestId
+	}
+	count := func(requestId int) int {
+		var n int
+		require.NoError(t, db.GetContext(ctx, &n, "SELECT COUNT(*) FROM ProjectRequest WHERE id = ?", requestId))
+		return n
+	}
+
+	_, roleId, requestId := cancelled()
+	require.NoError(t, NewProjectRolesRepository(db).Delete(ctx, roleId))
+	assert.Zero(t, count(requestId))
+
+	projectId, _, requestId := cancelled()
+	require.NoError(t, NewProjectRepository(db).Delete(ctx, projectId))
+	assert.Zero(t, count(requestId))
+}
+
+func TestApplyToProject_ConcurrentLimits(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+	f := newFixture(t, db)
+	repo := NewProjectActionsRepository(db)
+
+	const applicants = 5
+	var userIds []int
+	for i := 0; i < applicants; i++ {
+		userId := f.exec(t, "INSERT INTO User (name, email, password) VALUES (?, ?, '')", "applicant",
+			fmt.Sprintf("limited-%d-%d@example.com", i, time.Now().UnixNano()))
+		f.userIds = append(f.userIds, userId)
+		userIds = append(userIds, userId)
+	}
+
+	errs := make([]error, applicants)
+	var wg sync.WaitGroup
+	for i, userId := range userIds {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			req := domain.ProjectActionRequest{ProjectId: f.projectId, RoleId: f.roleId, UserId: userId}
+			_, errs[i] = repo.ApplyToProject(ctx, req, nil, domain.ApplyLimits{MaxOpenPerRole: 2})
+		}()
+	}
+	wg.Wait()
+
+	applied := 0
+	for _, err := range errs {
+		if err == nil {
+			applied++
+		} else {
+			assert.ErrorIs(t, err, domain.ErrRoleApplicationLimit)
+		}
+	}
+	assert.Equal(t, 2, applied)
+
+	// the same user applying to two roles at once is held to their limit too
+	otherRole := f.exec(t, "INSERT INTO ProjectRole (project_id, title, description, experience_level, is_filled) VALUES (?, 'frontend', '', 'mid', false)", f.projectId)
+	t.Cleanup(func() {
+		db.Exec("DELETE FROM ProjectRequest WHERE role_id = ?", otherRole)
+		db.Exec("DELETE FROM ProjectRole WHERE id = ?", otherRole)
+	})
+	userId := f.exec(t, "INSERT INTO User (name, email, password) VALUES (?, ?, '')", "applicant",
+		fmt.Sprintf("busy-%d@example.com", time.Now().UnixNano()))
+	f.userIds = append(f.userIds, userId)
+	for _, roleId := range []int{f.roleId, otherRole} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			req := domain.ProjectActionRequest{ProjectId: f.projectId, RoleId: roleId, UserId: userId}
+			repo.ApplyToProject(ctx, req, nil, domain.ApplyLimits{MaxOpenPerUser: 1})
+		}()
+	}
+	wg.Wait()
+
+	var open int
+	require.NoError(t, db.GetContext(ctx, &open, "SELECT COUNT(*) FROM ProjectRequest WHERE user_id = ? AND status = 'pending'", userId))
+	assert.Equal(t, 1, open)
+
+	// a double submit of the same application stores one request
+	twiceId := f.exec(t, "INSERT INTO User (name, email, password) VALUES (?, ?, '')", "applicant",
+		fmt.Sprintf("twice-%d@example.com", time.Now().UnixNano()))
+	f.userIds = append(f.userIds, twiceId)
+	twice := make([]error, 2)
+	for i := range twice {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			req := domain.ProjectActionRequest{ProjectId: f.projectId, RoleId: otherRole, UserId: twiceId}
+			_, twice[i] = repo.ApplyToProject(ctx, req, nil, domain.ApplyLimits{})
+		}()
+	}
+	wg.Wait()
+
+	applied = 0
+	for _, err := range twice {
+		if err == nil {
+			applied++
+		} else {
+			assert.ErrorIs(t, err, domain.ErrRequestAlreadyExists)
+		}
+	}
+	assert.Equal(t, 1, applied)
+
+	// an invitation sent to the user's email before they signed up counts too
+	email := fmt.Sprintf("Invited-%d@example.com", time.Now().UnixNano())
+	f.exec(t, `INSERT INTO ProjectRequest (project_id, role_id, direction, invitee_email, invited_by, expires_at)
+		VALUES (?, ?, 'invitation', ?, ?, NOW() + INTERVAL 7 DAY)`, f.projectId, otherRole, strings.ToLower(email), f.ownerId)
+	invitedId := f.exec(t, "INSERT INTO User (name, email, password) VALUES (?, ?, '')", "invited", email)
+	f.userIds = append(f.userIds, invitedId)
+	_, err := repo.ApplyToProject(ctx, domain.ProjectActionRequest{ProjectId: f.projectId, RoleId: otherRole, UserId: invitedId}, nil, domain.ApplyLimits{})
+	assert.ErrorIs(t, err, domain.ErrRequestAlreadyExists)
+}
@@ -0,0 +1,201 @@
+package repository
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/iemran93/devMatch/domain"
+	"github.com/iemran93/devMatch/internal/cache"
+)
+
+// ProjectCacheInvalidator is implemented by repositories that cache project
+// data. Usecases that change a project through another repository (roles,
+// requests, taxonomy) type-assert their ProjectRepository to it.
+type ProjectCacheInvalidator interface {
+	// InvalidateProject drops the cached details of one project.
+	InvalidateProject(id int)
+	// InvalidateTaxonomy drops the lookup lists and every cached project,
+	// since project details embed taxonomy names.
+	InvalidateTaxonomy()
+}
+
+// InvalidateProject is a no-op unless repo caches projects.
+func InvalidateProject(repo ProjectRepository, id int) {
+	if c, ok := repo.(ProjectCacheInvalidator); ok {
+		c.InvalidateProject(id)
+	}
+}
+
+// InvalidateTaxonomy is a no-op unless repo caches taxonomy.
+func InvalidateTaxonomy(repo ProjectRepository) {
+	if c, ok := repo.(ProjectCacheInvalidator); ok {
+		c.InvalidateTaxonomy()
+	}
+}
+
+// projectStripes is the number of invalidation generations projects are
+// spread over, so the counters stay bounded however many ids are cached.
+const projectStripes = 256
+
+// cachedProjectRepository decorates a ProjectRepository. Taxonomy lists are
+// kept for a TTL, project details in a bounded LRU. Everything else passes
+// through. The cache is per process; run one instance or keep TTLs short.
+//
+// Every invalidation bumps the generation of the project's stripe, or of the
+// taxonomy. A value read from the database is only cached when its
+// generation did not change during the read, so a row read before a write
+// committed is never cached after the write invalidated it.
+type cachedProjectRepository struct {
+	ProjectRepository
+	taxonomy *cache.LRU[domain.TaxonomyKind, any]
+	projects *cache.LRU[int, domain.ProjectResponse]
+
+	mu                 sync.Mutex
+	generations        [projectStripes]uint64
+	taxonomyGeneration uint64
+}
+
+// NewCachedProjectRepository wraps repo. A zero TTL or size disables the
+// cache it applies to.
+func NewCachedProjectRepository(repo ProjectRepository, taxonomyTTL time.Duration, projectSize int, projectTTL time.Duration) ProjectRepository {
+	taxonomySize := 4
+	if taxonomyTTL <= 0 {
+		taxonomySize = 0
+	}
+	if projectTTL <= 0 {
+		projectSize = 0
+	}
+
+	return &cachedProjectRepository{
+		ProjectRepository: repo,
+		taxonomy:          cache.NewLRU[domain.TaxonomyKind, any](taxonomySize, taxonomyTTL),
+		projects:          cache.NewLRU[int, domain.ProjectResponse](projectSize, projectTTL),
+	}
+}
+
+func (r *cachedProjectRepository) InvalidateProject(id int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.generations[stripe(id)]++
+	r.projects.Delete(id)
+}
+
+func (r *cachedProjectRepository) InvalidateTaxonomy() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range r.generations {
+		r.generations[i]++
+	}
+	r.taxonomyGeneration++
+	r.taxonomy.Purge()
+	r.projects.Purge()
+}
+
+func (r *cachedProjectRepository) GetById(ctx context.Context, id int) (*domain.ProjectResponse, error) {
+	if project, ok := r.projects.Get(id); ok {
+		return cloneProject(&project), nil
+	}
+
+	r.mu.Lock()
+	generation := r.generations[stripe(id)]
+	r.mu.Unlock()
+
+	project, err := r.ProjectRepository.GetById(ctx, id)
+	if err != nil || project == nil {
+		return project, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.generations[stripe(id)] == generation {
+		r.projects.Set(id, *cloneProject(project))
+	}
+	return project, nil
+}
+
+func (r *cachedProjectRepository) Update(ctx context.Context, req *domain.UpdateProjectRequest, id int) error {
+	defer r.InvalidateProject(id)
+	return r.ProjectRepository.Update(ctx, req, id)
+}
+
+func (r *cachedProjectRepository) Patch(ctx context.Context, req *domain.PatchProjectRequest, id int) error {
+	defer r.InvalidateProject(id)
+	return r.ProjectRepository.Patch(ctx, req, id)
+}
+
+func (r *cachedProjectRepository) Delete(ctx context.Context, id int) error {
+	defer r.InvalidateProject(id)
+	return r.ProjectRepository.Delete(ctx, id)
+}
+
+func stripe(id int) int {
+	return int(uint(id) % projectStripes)
+}
+
+// cloneProject copies p deeply, so callers never share slices with the
+// cached entry.
+func cloneProject(p *domain.ProjectResponse) *domain.ProjectResponse {
+	c := *p
+	if p.Goals != nil {
+		goals := *p.Goals
+		c.Goals = &goals
+	}
+	c.Types = slices.Clone(p.Types)
+	c.Technologies = slices.Clone(p.Technologies)
+	c.Languages = slices.Clone(p.Languages)
+	c.ProjectRoles = slices.Clone(p.ProjectRoles)
+	for i := range c.ProjectRoles {
+		c.ProjectRoles[i].Technologies = slices.Clone(p.ProjectRoles[i].Technologies)
+		c.ProjectRoles[i].Languages = slices.Clone(p.ProjectRoles[i].Languages)
+	}
+	return &c
+}
+
+func (r *cachedProjectRepository) GetCategory(ctx context.Context, q string) ([]domain.Category, error) {
+	return cachedLookup(r, ctx, domain.TaxonomyCategory, q, r.ProjectRepository.GetCategory)
+}
+
+func (r *cachedProjectRepository) GetTechnology(ctx context.Context, q string) ([]domain.Technology, error) {
+	return cachedLookup(r, ctx, domain.TaxonomyTechnology, q, r.ProjectRepository.GetTechnology)
+}
+
+func (r *cachedProjectRepository) GetLanguage(ctx context.Context, q string) ([]domain.Language, error) {
+	return cachedLookup(r, ctx, domain.TaxonomyLanguage, q, r.ProjectRepository.GetLanguage)
+}
+
+func (r *cachedProjectRepository) GetType(ctx context.Context, q string) ([]domain.Types, error) {
+	return cachedLookup(r, ctx, domain.TaxonomyType, q, r.ProjectRepository.GetType)
+}
+
+// cachedLookup serves the full list of a kind from cache. Autocomplete
+// queries go to the database, they are bounded and match aliases too.
+func cachedLookup[T any](r *cachedProjectRepository, ctx context.Context, kind domain.TaxonomyKind, q string,
+	load func(context.Context, string) ([]T, error)) ([]T, error) {
+	if q != "" {
+		return load(ctx, q)
+	}
+
+	if v, ok := r.taxonomy.Get(kind); ok {
+		return slices.Clone(v.([]T)), nil
+	}
+
+	r.mu.Lock()
+	generation := r.taxonomyGeneration
+	r.mu.Unlock()
+
+	list, err := load(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.taxonomyGeneration == generation {
+		r.taxonomy.Set(kind, slices.Clone(list))
+	}
+	return list, nil
+}
@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/iemran93/devMatch/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+// countingProjectRepository counts calls that reach the database.
+type countingProjectRepository struct {
+	ProjectRepository
+	getById     int
+	getLanguage int
+	updated     int
+	// duringGetById runs between reading and returning a project
+	duringGetById func()
+}
+
+func (r *countingProjectRepository) GetById(ctx context.Context, id int) (*domain.ProjectResponse, error) {
+	r.getById++
+	project := &domain.ProjectResponse{
+		Id:           id,
+		Title:        "devMatch",
+		Technologies: []domain.Technology{{Id: 1, Name: "Go"}},
+		ProjectRoles: []domain.ProjectRole{{Id: 1, Title: "backend", Technologies: []domain.RoleSkill{{Id: 1, Name: "Go"}}}},
+	}
+	if r.duringGetById != nil {
+		r.duringGetById()
+	}
+	return project, nil
+}
+
+func (r *countingProjectRepository) Update(ctx context.Context, req *domain.UpdateProjectRequest, id int) error {
+	r.updated++
+	return nil
+}
+
+func (r *countingProjectRepository) GetLanguage(ctx context.Context, q string) ([]domain.Language, error) {
+	r.getLanguage++
+	return []domain.Language{{Id: 1, Name: "Go"}}, nil
+}
+
+func TestCachedProjectRepository_GetById(t *testing.T) {
+	inner := &countingProjectRepository{}
+	repo := NewCachedProjectRepository(inner, time.Minute, 10, time.Minute)
+	ctx := context.Background()
+
+	p, err := repo.GetById(ctx, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, "devMatch", p.Title)
+
+	repo.GetById(ctx, 1)
+	assert.Equal(t, 1, inner.getById)
+
+	// writes through the decorator invalidate the entry
+	assert.NoError(t, repo.Update(ctx, &domain.UpdateProjectRequest{}, 1))
+	repo.GetById(ctx, 1)
+	assert.Equal(t, 2, inner.getById)
+
+	// writes elsewhere invalidate through the optional interface
+	InvalidateProject(repo, 1)
+	repo.GetById(ctx, 1)
+	assert.Equal(t, 3, inner.getById)
+}
+
+func TestCachedProjectRepository_Taxonomy(t *testing.T) {
+	inner := &countingProjectRepository{}
+	repo := NewCachedProjectRepository(inner, time.Minute, 10, time.Minute)
+	ctx := context.Background()
+
+	repo.GetLanguage(ctx, "")
+	repo.GetLanguage(ctx, "")
+	assert.Equal(t, 1, inner.getLanguage)
+
+	// autocomplete is not cached
+	repo.GetLanguage(ctx, "g")
+	assert.Equal(t, 2, inner.getLanguage)
+
+	InvalidateTaxonomy(repo)
+	repo.GetLanguage(ctx, "")
+	assert.Equal(t, 3, inner.getLanguage)
+}
+
+func TestCachedProjectRepository_Disabled(t *testing.T) {
+	inner := &countingProjectRepository{}
+	repo := NewCachedProjectRepository(inner, 0, 10, 0)
+	ctx := context.Background()
+
+	repo.GetById(ctx, 1)
+	repo.GetById(ctx, 1)
+	repo.GetLanguage(ctx, "")
+	repo.GetLanguage(ctx, "")
+
+	assert.Equal(t, 2, inner.getById)
+	assert.Equal(t, 2, inner.getLanguage)
+}
+
+func TestCachedProjectRepository_WriteDuringRead(t *testing.T) {
+	inner := &countingProjectRepository{}
+	repo := NewCachedProjectRepository(inner, time.Minute, 10, time.Minute)
+	ctx := context.Background()
+
+	// the row was read before the write committed and invalidated
+	inner.duringGetById = func() {
+		inner.duringGetById = nil
+		repo.Update(ctx, &domain.UpdateProjectRequest{}, 1)
+	}
+	repo.GetById(ctx, 1)
+
+	// so the stale row was not cached
+	repo.GetById(ctx, 1)
+	assert.Equal(t, 2, inner.getById)
+	repo.GetById(ctx, 1)
+	assert.Equal(t, 2, inner.getById)
+}
+
+func TestCachedProjectRepository_ReturnsCopies(t *testing.T) {
+	inner := &countingProjectRepository{}
+	repo := NewCachedProjectRepository(inner, time.Minute, 10, time.Minute)
+	ctx := context.Background()
+
+	first, err := repo.GetById(ctx, 1)
+	assert.NoError(t, err)
+	first.Technologies[0].Name = "changed"
+	first.ProjectRoles[0].Technologies[0].Name = "changed"
+
+	cached, err := repo.GetById(ctx, 1)
+	assert.NoError(t, err)
+	cached.ProjectRoles[0].Title = "changed"
+
+	again, err := repo.GetById(ctx, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, inner.getById)
+	assert.Equal(t, "Go", again.Technologies[0].Name)
+	assert.Equal(t, "Go", again.ProjectRoles[0].Technologies[0].Name)
+	assert.Equal(t, "backend", again.ProjectRoles[0].Title)
+}
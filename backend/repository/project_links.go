@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/iemran93/devMatch/domain"
+	"github.com/jmoiron/sqlx"
+)
+
+// projectLink is a many-to-many table between Project and a lookup table.
+// field is the JSON name of the collection in project requests.
+type projectLink struct {
+	field  string
+	table  string
+	column string
+}
+
+var (
+	linkProjectType  = projectLink{field: "project_type", table: "ProjectType", column: "type_id"}
+	linkTechnologies = projectLink{field: "technologies", table: "ProjectTechnology", column: "technology_id"}
+	linkLanguages    = projectLink{field: "languages", table: "ProjectLanguage", column: "language_id"}
+)
+
+func (l projectLink) current(ctx context.Context, tx *sqlx.Tx, projectId int) (map[int]bool, error) {
+	var ids []int
+	query := fmt.Sprintf("SELECT %s FROM %s WHERE project_id = ? FOR UPDATE", l.column, l.table)
+	if err := tx.SelectContext(ctx, &ids, query, projectId); err != nil {
+		return nil, err
+	}
+
+	have := make(map[int]bool, len(ids))
+	for _, id := range ids {
+		have[id] = true
+	}
+	return have, nil
+}
+
+// apply deletes and inserts only the given rows.
+func (l projectLink) apply(ctx context.Context, tx *sqlx.Tx, projectId int, add, remove []int) error {
+	if len(remove) > 0 {
+		query, args, err := sqlx.In(fmt.Sprintf("DELETE FROM %s WHERE project_id = ? AND %s IN (?)", l.table, l.column), projectId, remove)
+		if err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, tx.Rebind(query), args...); err != nil {
+			return err
+		}
+	}
+
+	for _, id := range add {
+		query := fmt.Sprintf("INSERT INTO %s (project_id, %s) VALUES (?, ?)", l.table, l.column)
+		if _, err := tx.ExecContext(ctx, query, projectId, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// syncLinks makes the collection equal to want, touching only the rows that
+// differ.
+func syncLinks(ctx context.Context, tx *sqlx.Tx, l projectLink, projectId int, want []int) error {
+	have, err := l.current(ctx, tx, projectId)
+	if err != nil {
+		return err
+	}
+
+	wanted := make(map[int]bool, len(want))
+	var add, remove []int
+	for _, id := range want {
+		wanted[id] = true
+		if !have[id] {
+			add = append(add, id)
+		}
+	}
+	for id := range have {
+		if !wanted[id] {
+			remove = append(remove, id)
+		}
+	}
+	return l.apply(ctx, tx, projectId, add, remove)
+}
+
+// patchLinks applies a LinkPatch. Adding a linked id or removing an unlinked
+// one is a no-op; a collection may not end up empty.
+func patchLinks(ctx context.Context, tx *sqlx.Tx, l projectLink, projectId int, patch *domain.LinkPatch) error {
+	if patch == nil {
+		return nil
+	}
+
+	have, err := l.current(ctx, tx, projectId)
+	if err != nil {
+		return err
+	}
+
+	var add, remove []int
+	for _, id := range patch.Add {
+		if !have[id] {
+			add = append(add, id)
+		}
+	}
+	for _, id := range patch.Remove {
+		if have[id] {
+			remove = append(remove, id)
+		}
+	}
+
+	if len(have)+len(add)-len(remove) == 0 {
+		return &domain.ValidationError{Errors: []domain.FieldError{
+			{Field: l.field, Rule: "min", Message: "must contain at least 1 items"},
+		}}
+	}
+	return l.apply(ctx, tx, projectId, add, remove)
+}
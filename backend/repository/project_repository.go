@@ -1,8 +1,11 @@
 package repository
 
 import (
+	"cmp"
 	"context"
 	"database/sql"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/iemran93/devMatch/domain"
@@ -15,11 +18,12 @@ type ProjectRepository interface {
 	GetByProjectId(ctx context.Context, id int) ([]*domain.ProjectRole, error)
 	List(ctx context.Context, filters map[string]any) ([]domain.ProjectResponse, error)
 	Update(ctx context.Context, req *domain.UpdateProjectRequest, id int) error
+	Patch(ctx context.Context, req *domain.PatchProjectRequest, id int) error
 	Delete(ctx context.Context, id int) error
-	GetCategory(ctx context.Context) ([]domain.Category, error)
-	GetTechnology(ctx context.Context) ([]domain.Technology, error)
-	GetLanguage(ctx context.Context) ([]domain.Language, error)
-	GetType(ctx context.Context) ([]domain.Types, error)
+	GetCategory(ctx context.Context, q string) ([]domain.Category, error)
+	GetTechnology(ctx context.Context, q string) ([]domain.Technology, error)
+	GetLanguage(ctx context.Context, q string) ([]domain.Language, error)
+	GetType(ctx context.Context, q string) ([]domain.Types, error)
 	GetProjectRoles(ctx context.Context, projectId int) ([]domain.ProjectRole, error)
 }
 
@@ -34,12 +38,22 @@ func NewProjectRepository(db *sqlx.DB) ProjectRepository {
 }
 
 func (r *projectRepository) Create(ctx context.Context, req *domain.CreateProjectRequest, creator_id int) (int, error) {
-	tx, err := r.db.Beginx()
+	tx, err := r.db.BeginTxx(ctx, nil)
 	if err != nil {
 		return 0, err
 	}
 	defer tx.Rollback()
 
+	err = checkTaxonomy(ctx, tx, taxonomyRefs{
+		CategoryId:   req.CategoryId,
+		ProjectType:  req.ProjectType,
+		Technologies: req.Technologies,
+		Languages:    req.Languages,
+	}, false)
+	if err != nil {
+		return 0, err
+	}
+
 	project := domain.Project{
 		Title:       req.Title,
 		Description: req.Description,
@@ -51,18 +65,8 @@ func (r *projectRepository) Create(ctx context.Context, req *domain.CreateProjec
 		UpdatedAt:   time.Now(),
 	}
 
-	projectRole := []domain.ProjectRole{}
-	for _, role := range req.ProjectRoles {
-		projectRole = append(projectRole, domain.ProjectRole{
-			Title:                  role.Title,
-			Description:            role.Description,
-			RequiredExperienceLeve: role.RequiredExperienceLeve,
-			IsFilled:               false,
-		})
-	}
-
 	// Insert project
-	result, err := tx.NamedExec(`
+	result, err := tx.NamedExecContext(ctx, `
 		INSERT INTO Project (
 			title, description, goals, category_id,
 			stage, created_at, updated_at, creator_id
@@ -83,7 +87,7 @@ func (r *projectRepository) Create(ctx context.Context, req *domain.CreateProjec
 	// Insert technologies
 	if len(req.Technologies) > 0 {
 		for _, techId := range req.Technologies {
-			_, err = tx.Exec(`
+			_, err = tx.ExecContext(ctx, `
 				INSERT INTO ProjectTechnology (project_id, technology_id)
 				VALUES (?, ?)
 			`, projectId, techId)
@@ -96,7 +100,7 @@ func (r *projectRepository) Create(ctx context.Context, req *domain.CreateProjec
 	// Insert languages
 	if len(req.Languages) > 0 {
 		for _, langId := range req.Languages {
-			_, err = tx.Exec(`
+			_, err = tx.ExecContext(ctx, `
 				INSERT INTO ProjectLanguage (project_id, language_id)
 				VALUES (?, ?)
 			`, projectId, langId)
@@ -109,7 +113,7 @@ func (r *projectRepository) Create(ctx context.Context, req *domain.CreateProjec
 	// insert types
 	if len(req.ProjectType) > 0 {
 		for _, typeId := range req.ProjectType {
-			_, err = tx.Exec(`
+			_, err = tx.ExecContext(ctx, `
 				INSERT INTO ProjectType (project_id, type_id)
 				VALUES (?, ?)
 			`, projectId, typeId)
@@ -120,15 +124,21 @@ func (r *projectRepository) Create(ctx context.Context, req *domain.CreateProjec
 	}
 
 	// inser project roles
-	for _, role := range projectRole {
-		role.ProjectId = int(projectId)
-		_, err = tx.NamedExec(`
+	for i, role := range req.ProjectRoles {
+		result, err := tx.ExecContext(ctx, `
 			INSERT INTO ProjectRole (
-				project_id, title, description, required_experience_level, is_filled
-			) VALUES (
-				:project_id, :title, :description, :required_experience_level, :is_filled
-			)
-		`, role)
+				project_id, title, description, experience_level, is_filled, seats
+			) VALUES (?, ?, ?, ?, false, ?)
+		`, projectId, role.Title, role.Description, cmp.Or(role.ExperienceLevel, domain.ExperienceMid), max(role.Seats, 1))
+		if err != nil {
+			return 0, err
+		}
+
+		roleId, err := result.LastInsertId()
+		if err != nil {
+			return 0, err
+		}
+		err = setRoleSkills(ctx, tx, int(roleId), fmt.Sprintf("project_roles[%d].", i), role.Technologies, role.Languages)
 		if err != nil {
 			return 0, err
 		}
@@ -146,7 +156,7 @@ func (r *projectRepository) GetById(ctx context.Context, id int) (*domain.Projec
 	var project domain.ProjectResponse
 
 	// Get project basic info
-	err := r.db.Get(&project, `
+	err := r.db.GetContext(ctx, &project, `
 		SELECT 
 			p.id,
 			p.title,
@@ -155,6 +165,7 @@ func (r *projectRepository) GetById(ctx context.Context, id int) (*domain.Projec
 			p.stage,
 			p.created_at,
 			p.updated_at,
+			p.version,
 			u.id as "creator.id",
 			u.name as "creator.name",
 			u.email as "creator.email",
@@ -173,8 +184,8 @@ func (r *projectRepository) GetById(ctx context.Context, id int) (*domain.Projec
 	}
 
 	// Get technologies
-	err = r.db.Select(&project.Technologies, `
-		SELECT t.*
+	err = r.db.SelectContext(ctx, &project.Technologies, `
+		SELECT t.id, t.name
 		FROM Technology t
 		JOIN ProjectTechnology pt ON t.id = pt.technology_id
 		WHERE pt.project_id = ?
@@ -184,8 +195,8 @@ func (r *projectRepository) GetById(ctx context.Context, id int) (*domain.Projec
 	}
 
 	// Get languages
-	err = r.db.Select(&project.Languages, `
-		SELECT l.*
+	err = r.db.SelectContext(ctx, &project.Languages, `
+		SELECT l.id, l.name
 		FROM Language l
 		JOIN ProjectLanguage pl ON l.id = pl.language_id
 		WHERE pl.project_id = ?
@@ -195,8 +206,8 @@ func (r *projectRepository) GetById(ctx context.Context, id int) (*domain.Projec
 	}
 
 	// get types
-	err = r.db.Select(&project.Types, `
-			SELECT t.*
+	err = r.db.SelectContext(ctx, &project.Types, `
+			SELECT t.id, t.name
 			FROM Types t
 			JOIN ProjectType pt ON t.id = pt.type_id
 			WHERE pt.project_id = ?
@@ -206,14 +217,14 @@ func (r *projectRepository) GetById(ctx context.Context, id int) (*domain.Projec
 	}
 
 	// get roles
-	err = r.db.Select(&project.ProjectRoles, `
-		SELECT pr.*
-		FROM ProjectRole pr
-		WHERE pr.project_id = ?
-	`, id)
+	err = r.db.SelectContext(ctx, &project.ProjectRoles,
+		`SELECT `+roleColumns+` FROM ProjectRole WHERE project_id = ?`, id)
 	if err != nil {
 		return nil, err
 	}
+	if err := attachRoleSkills(ctx, r.db, rolePointers(project.ProjectRoles)...); err != nil {
+		return nil, err
+	}
 
 	return &project, nil
 }
@@ -223,7 +234,7 @@ func (r *projectRepository) List(ctx context.Context, filters map[string]interfa
 
 	query := `
 		SELECT DISTINCT
-			p.id, p.title, p.description, p.goals, p.stage, p.created_at, p.updated_at, p.creator_id, p.category_id,
+			p.id, p.title, p.description, p.goals, p.stage, p.created_at, p.updated_at, p.version, p.creator_id, p.category_id,
 			u.id as user_id, u.name as user_name, u.email as user_email,
 			c.id as category_id, c.name as category_name
 		FROM Project p
@@ -270,10 +281,33 @@ func (r *projectRepository) List(ctx context.Context, filters map[string]interfa
 		args = append(args, categoryId)
 	}
 
+	// role filters must all hold for the same open role
+	var roleClause string
+	if level, ok := filters["experience_level"].(string); ok {
+		roleClause += " AND r.experience_level = ?"
+		args = append(args, level)
+	}
+	if technologyId, ok := filters["role_technology_id"].(int); ok {
+		roleClause += " AND EXISTS (SELECT 1 FROM ProjectRoleSkill s WHERE s.role_id = r.id AND s.technology_id = ?)"
+		args = append(args, technologyId)
+	}
+	if languageId, ok := filters["role_language_id"].(int); ok {
+		roleClause += " AND EXISTS (SELECT 1 FROM ProjectRoleSkill s WHERE s.role_id = r.id AND s.language_id = ?)"
+		args = append(args, languageId)
+	}
+	if roleClause != "" {
+		if whereClause == "" {
+			whereClause = " WHERE "
+		} else {
+			whereClause += " AND "
+		}
+		whereClause += "EXISTS (SELECT 1 FROM ProjectRole r WHERE r.project_id = p.id AND r.is_filled = false" + roleClause + ")"
+	}
+
 	query += whereClause + " ORDER BY p.created_at DESC"
 
 	// Execute the query (you'll need to handle the scanning differently due to aliases)
-	rows, err := r.db.Query(query, args...)
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -288,7 +322,7 @@ func (r *projectRepository) List(ctx context.Context, filters map[string]interfa
 
 		err = rows.Scan(
 			&project.Id, &project.Title, &project.Description, &project.Goals,
-			&project.Stage, &project.CreatedAt, &project.UpdatedAt, &project.Creator.Id, &categoryId,
+			&project.Stage, &project.CreatedAt, &project.UpdatedAt, &project.Version, &project.Creator.Id, &categoryId,
 			&userId, &userName, &userEmail, &categoryId, &categoryName,
 		)
 		if err != nil {
@@ -303,8 +337,8 @@ func (r *projectRepository) List(ctx context.Context, filters map[string]interfa
 	// get technologies, languages, types
 	for i := range projects {
 		// Get technologies
-		err = r.db.Select(&projects[i].Technologies, `
-			SELECT t.*
+		err = r.db.SelectContext(ctx, &projects[i].Technologies, `
+			SELECT t.id, t.name
 			FROM Technology t
 			JOIN ProjectTechnology pt ON t.id = pt.technology_id
 			WHERE pt.project_id = ?
@@ -314,8 +348,8 @@ func (r *projectRepository) List(ctx context.Context, filters map[string]interfa
 		}
 
 		// Get languages
-		err = r.db.Select(&projects[i].Languages, `
-			SELECT l.*
+		err = r.db.SelectContext(ctx, &projects[i].Languages, `
+			SELECT l.id, l.name
 			FROM Language l
 			JOIN ProjectLanguage pl ON l.id = pl.language_id
 			WHERE pl.project_id = ?
@@ -325,8 +359,8 @@ func (r *projectRepository) List(ctx context.Context, filters map[string]interfa
 		}
 
 		// get types
-		err = r.db.Select(&projects[i].Types, `
-			SELECT t.*
+		err = r.db.SelectContext(ctx, &projects[i].Types, `
+			SELECT t.id, t.name
 			FROM Types t
 			JOIN ProjectType pt ON t.id = pt.type_id
 			WHERE pt.project_id = ?
@@ -336,26 +370,41 @@ func (r *projectRepository) List(ctx context.Context, filters map[string]interfa
 		}
 
 		// get roles
-		err = r.db.Select(&projects[i].ProjectRoles, `
-			SELECT pr.*
-			FROM ProjectRole pr
-			WHERE pr.project_id = ?
-		`, projects[i].Id)
+		err = r.db.SelectContext(ctx, &projects[i].ProjectRoles,
+			`SELECT `+roleColumns+` FROM ProjectRole WHERE project_id = ?`, projects[i].Id)
 		if err != nil {
 			return nil, err
 		}
 	}
 
+	var roles []*domain.ProjectRole
+	for i := range projects {
+		roles = append(roles, rolePointers(projects[i].ProjectRoles)...)
+	}
+	if err := attachRoleSkills(ctx, r.db, roles...); err != nil {
+		return nil, err
+	}
+
 	return projects, nil
 }
 
 func (r *projectRepository) Update(ctx context.Context, req *domain.UpdateProjectRequest, id int) error {
-	tx, err := r.db.Beginx()
+	tx, err := r.db.BeginTxx(ctx, nil)
 	if err != nil {
 		return err
 	}
 	defer tx.Rollback()
 
+	err = checkTaxonomy(ctx, tx, taxonomyRefs{
+		CategoryId:   req.CategoryId,
+		ProjectType:  req.ProjectType,
+		Technologies: req.Technologies,
+		Languages:    req.Languages,
+	}, true)
+	if err != nil {
+		return err
+	}
+
 	project := domain.Project{
 		Id:          id,
 		Title:       req.Title,
@@ -364,112 +413,178 @@ func (r *projectRepository) Update(ctx context.Context, req *domain.UpdateProjec
 		CategoryId:  req.CategoryId,
 		Stage:       req.Stage,
 		UpdatedAt:   time.Now(),
+		Version:     req.Version,
 	}
 
-	_, err = tx.NamedExec(`
+	// the version check makes concurrent edits fail instead of overwriting
+	result, err := tx.NamedExecContext(ctx, `
 		UPDATE Project SET
 			title = :title,
 			description = :description,
 			goals = :goals,
 			category_id = :category_id,
 			stage = :stage,
-			updated_at = :updated_at
-		WHERE id = :id
+			updated_at = :updated_at,
+			version = version + 1
+		WHERE id = :id AND version = :version
 	`, project)
 	if err != nil {
 		return err
 	}
-
-	_, err = tx.Exec("DELETE FROM ProjectTechnology WHERE project_id = ?", id)
+	affected, err := result.RowsAffected()
 	if err != nil {
 		return err
 	}
-
-	// update technologies
-	if len(req.Technologies) > 0 {
-		for _, techId := range req.Technologies {
-			_, err = tx.Exec(`
-				INSERT INTO ProjectTechnology (project_id, technology_id)
-				VALUES (?, ?)
-			`, id, techId)
-			if err != nil {
-				return err
-			}
+	if affected == 0 {
+		return domain.ErrVersionConflict
+	}
+
+	// only the link rows that changed are touched
+	for _, l := range []struct {
+		link projectLink
+		ids  []int
+	}{
+		{linkTechnologies, req.Technologies},
+		{linkLanguages, req.Languages},
+		{linkProjectType, req.ProjectType},
+	} {
+		if err := syncLinks(ctx, tx, l.link, id, l.ids); err != nil {
+			return err
 		}
 	}
 
-	// Update languages
-	_, err = tx.Exec("DELETE FROM ProjectLanguage WHERE project_id = ?", id)
+	// update roles
+	// different url
+
+	return tx.Commit()
+}
+
+func (r *projectRepository) Patch(ctx context.Context, req *domain.PatchProjectRequest, id int) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
 	if err != nil {
 		return err
 	}
+	defer tx.Rollback()
 
-	if len(req.Languages) > 0 {
-		for _, langId := range req.Languages {
-			_, err = tx.Exec(`
-				INSERT INTO ProjectLanguage (project_id, language_id)
-				VALUES (?, ?)
-			`, id, langId)
-			if err != nil {
-				return err
-			}
+	// only ids being linked are checked, existing links may be deprecated
+	refs := taxonomyRefs{}
+	if req.CategoryId != nil {
+		refs.CategoryId = *req.CategoryId
+	}
+	if req.ProjectType != nil {
+		refs.ProjectType = req.ProjectType.Add
+	}
+	if req.Technologies != nil {
+		refs.Technologies = req.Technologies.Add
+	}
+	if req.Languages != nil {
+		refs.Languages = req.Languages.Add
+	}
+	if err := checkTaxonomy(ctx, tx, refs, false); err != nil {
+		return err
+	}
+
+	sets := []string{"updated_at = ?", "version = version + 1"}
+	args := []any{time.Now()}
+	if req.Title != nil {
+		sets = append(sets, "title = ?")
+		args = append(args, *req.Title)
+	}
+	if req.Description != nil {
+		sets = append(sets, "description = ?")
+		args = append(args, *req.Description)
+	}
+	if req.Goals != nil {
+		sets = append(sets, "goals = ?")
+		if *req.Goals == "" {
+			args = append(args, nil)
+		} else {
+			args = append(args, *req.Goals)
 		}
 	}
+	if req.CategoryId != nil {
+		sets = append(sets, "category_id = ?")
+		args = append(args, *req.CategoryId)
+	}
+	if req.Stage != nil {
+		sets = append(sets, "stage = ?")
+		args = append(args, *req.Stage)
+	}
+	args = append(args, id, req.Version)
 
-	// Update types
-	_, err = tx.Exec("DELETE FROM ProjectType WHERE project_id = ?", id)
+	result, err := tx.ExecContext(ctx,
+		"UPDATE Project SET "+strings.Join(sets, ", ")+" WHERE id = ? AND version = ?",
+		args...)
 	if err != nil {
 		return err
 	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return domain.ErrVersionConflict
+	}
 
-	if len(req.ProjectType) > 0 {
-		for _, typeId := range req.ProjectType {
-			_, err = tx.Exec(`
-				INSERT INTO ProjectType (project_id, type_id)
-				VALUES (?, ?)
-			`, id, typeId)
-			if err != nil {
-				return err
-			}
+	for _, l := range []struct {
+		link  projectLink
+		patch *domain.LinkPatch
+	}{
+		{linkTechnologies, req.Technologies},
+		{linkLanguages, req.Languages},
+		{linkProjectType, req.ProjectType},
+	} {
+		if err := patchLinks(ctx, tx, l.link, id, l.patch); err != nil {
+			return err
 		}
 	}
 
-	// update roles
-	// different url
-
 	return tx.Commit()
 }
 
 func (r *projectRepository) Delete(ctx context.Context, id int) error {
-	tx, err := r.db.Beginx()
+	tx, err := r.db.BeginTxx(ctx, nil)
 	if err != nil {
 		return err
 	}
 	defer tx.Rollback()
 
 	// Delete related records first
-	_, err = tx.Exec("DELETE FROM ProjectTechnology WHERE project_id = ?", id)
+	_, err = tx.ExecContext(ctx, "DELETE FROM ProjectTechnology WHERE project_id = ?", id)
 	if err != nil {
 		return err
 	}
 
-	_, err = tx.Exec("DELETE FROM ProjectLanguage WHERE project_id = ?", id)
+	_, err = tx.ExecContext(ctx, "DELETE FROM ProjectLanguage WHERE project_id = ?", id)
 	if err != nil {
 		return err
 	}
 
-	_, err = tx.Exec("DELETE FROM ProjectType WHERE project_id = ?", id)
+	_, err = tx.ExecContext(ctx, "DELETE FROM ProjectType WHERE project_id = ?", id)
 	if err != nil {
 		return err
 	}
 
-	_, err = tx.Exec("DELETE FROM ProjectRole WHERE project_id = ?", id)
+	// requests are kept as history, so they go with the project; the rows
+	// hanging off them cascade
+	_, err = tx.ExecContext(ctx, "DELETE FROM ProjectRequest WHERE project_id = ?", id)
+	if err != nil {
+		return err
+	}
+
+	// notifications stay with their users
+	_, err = tx.ExecContext(ctx, "UPDATE Notification SET project_id = NULL WHERE project_id = ?", id)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, "DELETE FROM ProjectRole WHERE project_id = ?", id)
 	if err != nil {
 		return err
 	}
 
 	// Delete project
-	_, err = tx.Exec("DELETE FROM Project WHERE id = ?", id)
+	_, err = tx.ExecContext(ctx, "DELETE FROM Project WHERE id = ?", id)
 	if err != nil {
 		return err
 	}
@@ -477,36 +592,40 @@ func (r *projectRepository) Delete(ctx context.Context, id int) error {
 	return tx.Commit()
 }
 
-func (r *projectRepository) GetCategory(ctx context.Context) ([]domain.Category, error) {
+func (r *projectRepository) GetCategory(ctx context.Context, q string) ([]domain.Category, error) {
 	var categories []domain.Category
-	err := r.db.Select(&categories, "SELECT id, name FROM Category")
+	query, args := lookupQuery("Category", domain.TaxonomyCategory, q)
+	err := r.db.SelectContext(ctx, &categories, query, args...)
 	if err != nil {
 		return nil, err
 	}
 	return categories, nil
 }
 
-func (r *projectRepository) GetTechnology(ctx context.Context) ([]domain.Technology, error) {
+func (r *projectRepository) GetTechnology(ctx context.Context, q string) ([]domain.Technology, error) {
 	var technologies []domain.Technology
-	err := r.db.Select(&technologies, "SELECT id, name FROM Technology")
+	query, args := lookupQuery("Technology", domain.TaxonomyTechnology, q)
+	err := r.db.SelectContext(ctx, &technologies, query, args...)
 	if err != nil {
 		return nil, err
 	}
 	return technologies, nil
 }
 
-func (r *projectRepository) GetLanguage(ctx context.Context) ([]domain.Language, error) {
+func (r *projectRepository) GetLanguage(ctx context.Context, q string) ([]domain.Language, error) {
 	var languages []domain.Language
-	err := r.db.Select(&languages, "SELECT id, name FROM Language")
+	query, args := lookupQuery("Language", domain.TaxonomyLanguage, q)
+	err := r.db.SelectContext(ctx, &languages, query, args...)
 	if err != nil {
 		return nil, err
 	}
 	return languages, nil
 }
 
-func (r *projectRepository) GetType(ctx context.Context) ([]domain.Types, error) {
+func (r *projectRepository) GetType(ctx context.Context, q string) ([]domain.Types, error) {
 	var types []domain.Types
-	err := r.db.Select(&types, "SELECT id, name FROM Types")
+	query, args := lookupQuery("Types", domain.TaxonomyType, q)
+	err := r.db.SelectContext(ctx, &types, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -515,21 +634,24 @@ func (r *projectRepository) GetType(ctx context.Context) ([]domain.Types, error)
 
 func (r *projectRepository) GetProjectRoles(ctx context.Context, projectId int) ([]domain.ProjectRole, error) {
 	var roles []domain.ProjectRole
-	err := r.db.Select(&roles, "SELECT * FROM ProjectRole WHERE project_id = ?", projectId)
+	err := r.db.SelectContext(ctx, &roles, "SELECT "+roleColumns+" FROM ProjectRole WHERE project_id = ?", projectId)
 	if err != nil {
 		return nil, err
 	}
+	if err := attachRoleSkills(ctx, r.db, rolePointers(roles)...); err != nil {
+		return nil, err
+	}
 	return roles, nil
 }
 func (r *projectRepository) GetByProjectId(ctx context.Context, id int) ([]*domain.ProjectRole, error) {
-	tx, err := r.db.BeginTx(ctx, nil)
+	tx, err := r.db.BeginTxx(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
 
 	defer tx.Rollback()
 
-	query := `SELECT * FROM ProjectRole WHERE project_id=?`
+	query := `SELECT ` + roleColumns + ` FROM ProjectRole WHERE project_id=?`
 	rows, err := tx.QueryContext(ctx, query, id)
 	if err != nil {
 		return nil, err
@@ -541,7 +663,8 @@ func (r *projectRepository) GetByProjectId(ctx context.Context, id int) ([]*doma
 		var pr domain.ProjectRole
 		err := rows.Scan(
 			&pr.Id, &pr.ProjectId, &pr.Title, &pr.Description,
-			&pr.RequiredExperienceLeve, &pr.IsFilled)
+			&pr.ExperienceLevel, &pr.IsFilled, &pr.Seats, &pr.FilledSeats,
+			&pr.SeatsRemaining, &pr.Version)
 		if err != nil {
 			return nil, err
 		}
@@ -551,6 +674,10 @@ func (r *projectRepository) GetByProjectId(ctx context.Context, id int) ([]*doma
 	if err := rows.Err(); err != nil {
 		return nil, err
 	}
+	rows.Close()
+	if err := attachRoleSkills(ctx, tx, projectRoles...); err != nil {
+		return nil, err
+	}
 	if err := tx.Commit(); err != nil {
 		return nil, err
 	}
@@ -1,12 +1,18 @@
 package repository
 
 import (
+	"cmp"
 	"context"
+	"fmt"
 
 	"github.com/iemran93/devMatch/domain"
 	"github.com/jmoiron/sqlx"
 )
 
+// roleColumns selects a ProjectRole with its remaining seats.
+const roleColumns = `id, project_id, title, description, experience_level,
+	is_filled, seats, filled_seats, seats - filled_seats AS seats_remaining, version`
+
 type ProjectRolesRepository struct {
 	db *sqlx.DB
 }
@@ -17,6 +23,11 @@ type ProjectRolesInterface interface {
 	// id is role id /{id}
 	Update(ctx context.Context, req *domain.ProjectRoleRequest, id int) (*domain.ProjectRole, error)
 	Delete(ctx context.Context, id int) error
+	ListQuestions(ctx context.Context, roleId int) ([]domain.RoleQuestion, error)
+	GetQuestion(ctx context.Context, id int) (*domain.RoleQuestion, error)
+	CreateQuestion(ctx context.Context, roleId int, req *domain.RoleQuestionRequest) (int, error)
+	UpdateQuestion(ctx context.Context, id int, req *domain.RoleQuestionRequest) error
+	DeleteQuestion(ctx context.Context, id int) error
 }
 
 func NewProjectRolesRepository(db *sqlx.DB) ProjectRolesInterface {
@@ -26,7 +37,7 @@ func NewProjectRolesRepository(db *sqlx.DB) ProjectRolesInterface {
 }
 
 func (prr *ProjectRolesRepository) Create(ctx context.Context, req *domain.ProjectRoleRequest) (*domain.ProjectRole, error) {
-	tx, err := prr.db.BeginTx(ctx, nil)
+	tx, err := prr.db.BeginTxx(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -34,16 +45,19 @@ func (prr *ProjectRolesRepository) Create(ctx context.Context, req *domain.Proje
 	defer tx.Rollback()
 
 	pr := domain.ProjectRole{
-		ProjectId:              req.ProjectId,
-		Title:                  req.Title,
-		Description:            req.Description,
-		RequiredExperienceLeve: req.RequiredExperienceLeve,
-		IsFilled:               false,
+		ProjectId:       req.ProjectId,
+		Title:           req.Title,
+		Description:     req.Description,
+		ExperienceLevel: cmp.Or(req.ExperienceLevel, domain.ExperienceMid),
+		IsFilled:        false,
+		Seats:           max(req.Seats, 1),
+		Version:         1,
 	}
+	pr.SeatsRemaining = pr.Seats
 
-	result, err := tx.ExecContext(ctx, `INSERT INTO ProjectRole (project_id, title, description, required_experience_level, is_filled)
-		VALUES (?, ?, ?, ?, ?)`,
-		pr.ProjectId, pr.Title, pr.Description, pr.RequiredExperienceLeve, pr.IsFilled)
+	result, err := tx.ExecContext(ctx, `INSERT INTO ProjectRole (project_id, title, description, experience_level, is_filled, seats)
+		VALUES (?, ?, ?, ?, ?, ?)`,
+		pr.ProjectId, pr.Title, pr.Description, pr.ExperienceLevel, pr.IsFilled, pr.Seats)
 
 	if err != nil {
 		return nil, err
@@ -55,6 +69,13 @@ func (prr *ProjectRolesRepository) Create(ctx context.Context, req *domain.Proje
 	}
 	pr.Id = int(roleId)
 
+	if err := setRoleSkills(ctx, tx, pr.Id, "", req.Technologies, req.Languages); err != nil {
+		return nil, err
+	}
+	if err := attachRoleSkills(ctx, tx, &pr); err != nil {
+		return nil, err
+	}
+
 	if err := tx.Commit(); err != nil {
 		return nil, err
 	}
@@ -64,41 +85,73 @@ func (prr *ProjectRolesRepository) Create(ctx context.Context, req *domain.Proje
 func (prr *ProjectRolesRepository) Get(ctx context.Context, id int) (*domain.ProjectRole, error) {
 	var pr domain.ProjectRole
 
-	query := `SELECT * FROM ProjectRole WHERE id=?`
+	query := `SELECT ` + roleColumns + ` FROM ProjectRole WHERE id=?`
 	err := prr.db.GetContext(ctx, &pr, query, id)
 	if err != nil {
 		return nil, err
 	}
+	if err := attachRoleSkills(ctx, prr.db, &pr); err != nil {
+		return nil, err
+	}
 
 	return &pr, nil
 }
 
 func (prr *ProjectRolesRepository) Update(ctx context.Context, req *domain.ProjectRoleRequest, id int) (*domain.ProjectRole, error) {
-	tx, err := prr.db.BeginTx(ctx, nil)
+	tx, err := prr.db.BeginTxx(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
 
 	defer tx.Rollback()
 
-	query := `UPDATE ProjectRole SET title=?, description=?, required_experience_level=?, is_filled=? WHERE id=?`
+	var current struct {
+		Seats       int `db:"seats"`
+		FilledSeats int `db:"filled_seats"`
+		Version     int `db:"version"`
+	}
+	err = tx.GetContext(ctx, &current, `SELECT seats, filled_seats, version FROM ProjectRole WHERE id=? FOR UPDATE`, id)
+	if err != nil {
+		return nil, err
+	}
+	if current.Version != req.Version {
+		return nil, domain.ErrVersionConflict
+	}
+
+	seats := current.Seats
+	if req.Seats > 0 {
+		seats = req.Seats
+	}
+	if seats < current.FilledSeats {
+		return nil, &domain.ValidationError{Errors: []domain.FieldError{{
+			Field:   "seats",
+			Rule:    "min",
+			Message: fmt.Sprintf("must be at least %d, the seats already filled", current.FilledSeats),
+		}}}
+	}
 
-	_, err = tx.ExecContext(ctx, query, req.Title, req.Description, req.RequiredExperienceLeve, req.IsFilled, id)
+	query := `UPDATE ProjectRole SET title=?, description=?, experience_level=COALESCE(NULLIF(?, ''), experience_level),
+		seats=?, is_filled=(filled_seats >= seats), version=version+1
+		WHERE id=?`
+	_, err = tx.ExecContext(ctx, query, req.Title, req.Description, req.ExperienceLevel, seats, id)
 	if err != nil {
 		return nil, err
 	}
 
-	if err := tx.Commit(); err != nil {
+	if err := setRoleSkills(ctx, tx, id, "", req.Technologies, req.Languages); err != nil {
+		return nil, err
+	}
+
+	var pr domain.ProjectRole
+	if err := tx.GetContext(ctx, &pr, `SELECT `+roleColumns+` FROM ProjectRole WHERE id=?`, id); err != nil {
+		return nil, err
+	}
+	if err := attachRoleSkills(ctx, tx, &pr); err != nil {
 		return nil, err
 	}
 
-	var pr = domain.ProjectRole{
-		Id:                     id,
-		ProjectId:              req.ProjectId,
-		Title:                  req.Title,
-		Description:            req.Description,
-		RequiredExperienceLeve: req.RequiredExperienceLeve,
-		IsFilled:               req.IsFilled,
+	if err := tx.Commit(); err != nil {
+		return nil, err
 	}
 
 	return &pr, nil
@@ -112,6 +165,13 @@ func (prr *ProjectRolesRepository) Delete(ctx context.Context, id int) error {
 
 	defer tx.Rollback()
 
+	// requests are kept as history, so they go with the role; the rows
+	// hanging off them cascade
+	_, err = tx.ExecContext(ctx, `DELETE FROM ProjectRequest WHERE role_id=?`, id)
+	if err != nil {
+		return err
+	}
+
 	_, err = tx.ExecContext(ctx, `DELETE FROM ProjectRole WHERE id=?`, id)
 	if err != nil {
 		return err
@@ -123,3 +183,43 @@ func (prr *ProjectRolesRepository) Delete(ctx context.Context, id int) error {
 
 	return nil
 }
+
+func (prr *ProjectRolesRepository) ListQuestions(ctx context.Context, roleId int) ([]domain.RoleQuestion, error) {
+	questions := make([]domain.RoleQuestion, 0)
+	query := `SELECT * FROM ProjectRoleQuestion WHERE role_id=? ORDER BY position, id`
+	if err := prr.db.SelectContext(ctx, &questions, query, roleId); err != nil {
+		return nil, err
+	}
+	return questions, nil
+}
+
+func (prr *ProjectRolesRepository) GetQuestion(ctx context.Context, id int) (*domain.RoleQuestion, error) {
+	var q domain.RoleQuestion
+	if err := prr.db.GetContext(ctx, &q, `SELECT * FROM ProjectRoleQuestion WHERE id=?`, id); err != nil {
+		return nil, err
+	}
+	return &q, nil
+}
+
+func (prr *ProjectRolesRepository) CreateQuestion(ctx context.Context, roleId int, req *domain.RoleQuestionRequest) (int, error) {
+	result, err := prr.db.ExecContext(ctx, `INSERT INTO ProjectRoleQuestion (role_id, prompt, kind, options, required, position)
+		VALUES (?, ?, ?, ?, ?, ?)`,
+		roleId, req.Prompt, req.Kind, domain.StringList(req.Options), req.Required, req.Position)
+	if err != nil {
+		return 0, err
+	}
+
+	id, err := result.LastInsertId()
+	return int(id), err
+}
+
+func (prr *ProjectRolesRepository) UpdateQuestion(ctx context.Context, id int, req *domain.RoleQuestionRequest) error {
+	_, err := prr.db.ExecContext(ctx, `UPDATE ProjectRoleQuestion SET prompt=?, kind=?, options=?, required=?, position=? WHERE id=?`,
+		req.Prompt, req.Kind, domain.StringList(req.Options), req.Required, req.Position, id)
+	return err
+}
+
+func (prr *ProjectRolesRepository) DeleteQuestion(ctx context.Context, id int) error {
+	_, err := prr.db.ExecContext(ctx, `DELETE FROM ProjectRoleQuestion WHERE id=?`, id)
+	return err
+}
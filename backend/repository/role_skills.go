@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/iemran93/devMatch/domain"
+	"github.com/jmoiron/sqlx"
+)
+
+// setRoleSkills replaces the technologies and languages of a role. A nil
+// list keeps the current rows of that kind. Unknown ids, and deprecated ids
+// the role does not list yet, are reported as a domain.ValidationError whose
+// fields start with prefix, e.g. "project_roles[0]." when the role is part of
+// a project create.
+func setRoleSkills(ctx context.Context, tx *sqlx.Tx, roleId int, prefix string, technologies, languages []domain.RoleSkillRequest) error {
+	var rows []roleSkillRow
+	err := tx.SelectContext(ctx, &rows, "SELECT role_id, technology_id, language_id FROM ProjectRoleSkill WHERE role_id = ?", roleId)
+	if err != nil {
+		return err
+	}
+	linkedTechnologies, linkedLanguages := make(map[int]bool), make(map[int]bool)
+	for _, row := range rows {
+		switch {
+		case row.TechnologyId != nil:
+			linkedTechnologies[*row.TechnologyId] = true
+		case row.LanguageId != nil:
+			linkedLanguages[*row.LanguageId] = true
+		}
+	}
+
+	// only ids being linked are checked, existing links may be deprecated
+	added := func(skills []domain.RoleSkillRequest, linked map[int]bool) []int {
+		var ids []int
+		for _, s := range skills {
+			if !linked[s.Id] {
+				ids = append(ids, s.Id)
+			}
+		}
+		return ids
+	}
+
+	err = checkTaxonomy(ctx, tx, taxonomyRefs{
+		Technologies: added(technologies, linkedTechnologies),
+		Languages:    added(languages, linkedLanguages),
+	}, false)
+	var ve *domain.ValidationError
+	if errors.As(err, &ve) {
+		for i := range ve.Errors {
+			ve.Errors[i].Field = prefix + ve.Errors[i].Field
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	lists := []struct {
+		column string
+		skills []domain.RoleSkillRequest
+	}{
+		{"technology_id", technologies},
+		{"language_id", languages},
+	}
+	for _, l := range lists {
+		if l.skills == nil {
+			continue
+		}
+
+		_, err := tx.ExecContext(ctx, fmt.Sprintf("DELETE FROM ProjectRoleSkill WHERE role_id = ? AND %s IS NOT NULL", l.column), roleId)
+		if err != nil {
+			return err
+		}
+		for _, s := range l.skills {
+			_, err := tx.ExecContext(ctx, fmt.Sprintf("INSERT INTO ProjectRoleSkill (role_id, %s, required) VALUES (?, ?, ?)", l.column),
+				roleId, s.Id, s.Required)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+type roleSkillRow struct {
+	RoleId       int    `db:"role_id"`
+	TechnologyId *int   `db:"technology_id"`
+	LanguageId   *int   `db:"language_id"`
+	Name         string `db:"name"`
+	Required     bool   `db:"required"`
+}
+
+// attachRoleSkills loads the technologies and languages of every role in a
+// single query, required ones first.
+func attachRoleSkills(ctx context.Context, q sqlx.QueryerContext, roles ...*domain.ProjectRole) error {
+	if len(roles) == 0 {
+		return nil
+	}
+
+	byId := make(map[int]*domain.ProjectRole, len(roles))
+	ids := make([]int, 0, len(roles))
+	for _, role := range roles {
+		role.Technologies = []domain.RoleSkill{}
+		role.Languages = []domain.RoleSkill{}
+		byId[role.Id] = role
+		ids = append(ids, role.Id)
+	}
+
+	query, args, err := sqlx.In(`
+		SELECT s.role_id, s.technology_id, s.language_id, COALESCE(t.name, l.name) AS name, s.required
+		FROM ProjectRoleSkill s
+		LEFT JOIN Technology t ON t.id = s.technology_id
+		LEFT JOIN Language l ON l.id = s.language_id
+		WHERE s.role_id IN (?)
+		ORDER BY s.required DESC, name
+	`, ids)
+	if err != nil {
+		return err
+	}
+
+	var rows []roleSkillRow
+	if err := sqlx.SelectContext(ctx, q, &rows, query, args...); err != nil {
+		return err
+	}
+
+	for _, row := range rows {
+		role := byId[row.RoleId]
+		switch {
+		case row.TechnologyId != nil:
+			role.Technologies = append(role.Technologies, domain.RoleSkill{Id: *row.TechnologyId, Name: row.Name, Required: row.Required})
+		case row.LanguageId != nil:
+			role.Languages = append(role.Languages, domain.RoleSkill{Id: *row.LanguageId, Name: row.Name, Required: row.Required})
+		}
+	}
+	return nil
+}
+
+// rolePointers returns pointers into roles so attachRoleSkills fills the
+// slice in place.
+func rolePointers(roles []domain.ProjectRole) []*domain.ProjectRole {
+	ptrs := make([]*domain.ProjectRole, len(roles))
+	for i := range roles {
+		ptrs[i] = &roles[i]
+	}
+	return ptrs
+}
@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/iemran93/devMatch/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetRoleSkills_Deprecated(t *testing.T) {
+	db := testDB(t)
+	f := newFixture(t, db)
+	ctx := context.Background()
+
+	suffix := time.Now().UnixNano()
+	linked := f.exec(t, "INSERT INTO Technology (name) VALUES (?)", fmt.Sprintf("linked-%d", suffix))
+	added := f.exec(t, "INSERT INTO Technology (name, deprecated) VALUES (?, true)", fmt.Sprintf("added-%d", suffix))
+	t.Cleanup(func() {
+		db.Exec("DELETE FROM ProjectRoleSkill WHERE role_id = ?", f.roleId)
+		db.Exec("DELETE FROM Technology WHERE id IN (?, ?)", linked, added)
+	})
+
+	set := func(ids ...int) error {
+		tx, err := db.BeginTxx(ctx, nil)
+		require.NoError(t, err)
+		defer tx.Rollback()
+
+		var skills []domain.RoleSkillRequest
+		for _, id := range ids {
+			skills = append(skills, domain.RoleSkillRequest{Id: id, Required: true})
+		}
+		if err := setRoleSkills(ctx, tx, f.roleId, "", skills, nil); err != nil {
+			return err
+		}
+		return tx.Commit()
+	}
+
+	require.NoError(t, set(linked))
+	f.exec(t, "UPDATE Technology SET deprecated = true WHERE id = ?", linked)
+
+	// the role keeps the technology it already lists
+	assert.NoError(t, set(linked))
+
+	// but cannot pick up another deprecated one
+	var ve *domain.ValidationError
+	err := set(linked, added)
+	require.ErrorAs(t, err, &ve)
+	require.Len(t, ve.Errors, 1)
+	assert.Equal(t, domain.FieldError{Field: "technologies", Rule: "deprecated", Message: fmt.Sprintf("deprecated ids: %d", added)}, ve.Errors[0])
+}
@@ -0,0 +1,156 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/iemran93/devMatch/domain"
+	"github.com/jmoiron/sqlx"
+)
+
+// taxonomyRefs are the lookup ids a project create or update points at,
+// keyed by the JSON field they came from. A zero CategoryId or an empty list
+// is not checked.
+type taxonomyRefs struct {
+	CategoryId   int
+	ProjectType  []int
+	Technologies []int
+	Languages    []int
+}
+
+type taxonomyRow struct {
+	Field      string `db:"field"`
+	Id         int    `db:"id"`
+	Deprecated bool   `db:"deprecated"`
+}
+
+// checkTaxonomy looks every referenced id up in a single query and returns a
+// domain.ValidationError naming the ids that do not exist. Deprecated entries
+// are rejected too unless allowDeprecated is set, so projects that already
+// use one can still be edited.
+func checkTaxonomy(ctx context.Context, tx *sqlx.Tx, refs taxonomyRefs, allowDeprecated bool) error {
+	var parts []string
+	var args []any
+	if refs.CategoryId != 0 {
+		parts = append(parts, "SELECT 'category_id' AS field, id, deprecated FROM Category WHERE id = ?")
+		args = append(args, refs.CategoryId)
+	}
+
+	lists := []struct {
+		field string
+		table string
+		ids   []int
+	}{
+		{"project_type", "Types", refs.ProjectType},
+		{"technologies", "Technology", refs.Technologies},
+		{"languages", "Language", refs.Languages},
+	}
+	for _, l := range lists {
+		if len(l.ids) == 0 {
+			continue
+		}
+		parts = append(parts, fmt.Sprintf("SELECT '%s' AS field, id, deprecated FROM %s WHERE id IN (?)", l.field, l.table))
+		args = append(args, l.ids)
+	}
+
+	if len(parts) == 0 {
+		return nil
+	}
+
+	query, args, err := sqlx.In(strings.Join(parts, " UNION ALL "), args...)
+	if err != nil {
+		return err
+	}
+
+	var rows []taxonomyRow
+	if err := tx.SelectContext(ctx, &rows, tx.Rebind(query), args...); err != nil {
+		return err
+	}
+
+	// found[field][id] holds the deprecated flag of every existing id
+	found := make(map[string]map[int]bool)
+	for _, row := range rows {
+		if found[row.Field] == nil {
+			found[row.Field] = make(map[int]bool)
+		}
+		found[row.Field][row.Id] = row.Deprecated
+	}
+
+	var fields []domain.FieldError
+	check := func(field string, ids []int) {
+		var missing, deprecated []int
+		for _, id := range ids {
+			isDeprecated, ok := found[field][id]
+			if !ok {
+				missing = append(missing, id)
+			} else if isDeprecated && !allowDeprecated {
+				deprecated = append(deprecated, id)
+			}
+		}
+		if len(missing) > 0 {
+			fields = append(fields, idsError(field, "exists", "unknown ids: ", missing))
+		}
+		if len(deprecated) > 0 {
+			fields = append(fields, idsError(field, "deprecated", "deprecated ids: ", deprecated))
+		}
+	}
+
+	if refs.CategoryId != 0 {
+		check("category_id", []int{refs.CategoryId})
+	}
+	for _, l := range lists {
+		check(l.field, l.ids)
+	}
+
+	if len(fields) > 0 {
+		return &domain.ValidationError{Errors: fields}
+	}
+	return nil
+}
+
+func idsError(field, rule, prefix string, ids []int) domain.FieldError {
+	strs := make([]string, len(ids))
+	for i, id := range ids {
+		strs[i] = strconv.Itoa(id)
+	}
+	return domain.FieldError{
+		Field:   field,
+		Rule:    rule,
+		Message: prefix + strings.Join(strs, ", "),
+	}
+}
+
+// lookupLimit caps autocomplete results.
+const lookupLimit = 20
+
+// lookupQuery lists the live entries of a lookup table. With a non empty q
+// only entries whose name or one of whose aliases starts with q are returned.
+func lookupQuery(table string, kind domain.TaxonomyKind, q string) (string, []any) {
+	q = strings.TrimSpace(q)
+	if q == "" {
+		return fmt.Sprintf("SELECT id, name FROM %s WHERE deprecated = false ORDER BY name", table), nil
+	}
+
+	query := fmt.Sprintf(`
+		SELECT e.id, e.name
+		FROM %s e
+		WHERE e.deprecated = false AND (
+			e.name LIKE ?
+			OR e.id IN (
+				SELECT a.entry_id FROM TaxonomyAlias a
+				WHERE a.kind = ? AND a.normalized LIKE ?
+			)
+		)
+		ORDER BY e.name
+		LIMIT %d
+	`, table, lookupLimit)
+	return query, []any{likePrefix(q), kind, likePrefix(domain.NormalizeTaxonomyName(q))}
+}
+
+// likePrefix escapes LIKE wildcards in s and appends a trailing %.
+func likePrefix(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+	return r.Replace(s) + "%"
+}
@@ -0,0 +1,416 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/iemran93/devMatch/domain"
+	"github.com/jmoiron/sqlx"
+)
+
+type TaxonomyRepository interface {
+	List(ctx context.Context, kind domain.TaxonomyKind) ([]domain.TaxonomyEntry, error)
+	GetById(ctx context.Context, kind domain.TaxonomyKind, id int) (*domain.TaxonomyEntry, error)
+	Resolve(ctx context.Context, kind domain.TaxonomyKind, name string) (*domain.TaxonomyEntry, error)
+	Create(ctx context.Context, kind domain.TaxonomyKind, name string) (int, error)
+	Update(ctx context.Context, kind domain.TaxonomyKind, id int, req *domain.UpdateTaxonomyRequest) error
+	Merge(ctx context.Context, kind domain.TaxonomyKind, sourceId, targetId int) error
+	ListAliases(ctx context.Context, kind domain.TaxonomyKind, entryId int) ([]domain.TaxonomyAlias, error)
+	GetAlias(ctx context.Context, id int) (*domain.TaxonomyAlias, error)
+	CreateAlias(ctx context.Context, kind domain.TaxonomyKind, entryId int, alias string) (int, error)
+	DeleteAlias(ctx context.Context, id int) error
+	CreateProposal(ctx context.Context, name string, userId int) (int, error)
+	GetProposal(ctx context.Context, id int) (*domain.TechnologyProposal, error)
+	GetPendingProposalByName(ctx context.Context, name string) (*domain.TechnologyProposal, error)
+	ListProposals(ctx context.Context, status string) ([]domain.TechnologyProposal, error)
+	ReviewProposal(ctx context.Context, id int, reviewerId int, approve bool) error
+}
+
+// taxonomyTable describes where a kind is stored and which link tables
+// point at it.
+type taxonomyTable struct {
+	table string
+	// usage counts the projects referencing entry e
+	usage string
+	// links are "table.column" pairs re-pointed on merge
+	links []taxonomyLink
+}
+
+type taxonomyLink struct {
+	table  string
+	column string
+	// owner is set on links holding one row per (owner, column) pair, so
+	// rows that would become duplicates are dropped before re-pointing
+	owner string
+}
+
+var taxonomyTables = map[domain.TaxonomyKind]taxonomyTable{
+	domain.TaxonomyCategory: {
+		table: "Category",
+		usage: "SELECT COUNT(*) FROM Project p WHERE p.category_id = e.id",
+		links: []taxonomyLink{
+			{table: "Project", column: "category_id"},
+			{table: "UserSkill", column: "category_id"},
+		},
+	},
+	domain.TaxonomyTechnology: {
+		table: "Technology",
+		usage: "SELECT COUNT(DISTINCT l.project_id) FROM ProjectTechnology l WHERE l.technology_id = e.id",
+		links: []taxonomyLink{
+			{table: "ProjectTechnology", column: "technology_id", owner: "project_id"},
+			{table: "ProjectRoleSkill", column: "technology_id", owner: "role_id"},
+			{table: "UserSkill", column: "technology_id"},
+			{table: "TechnologyProposal", column: "technology_id"},
+		},
+	},
+	domain.TaxonomyLanguage: {
+		table: "Language",
+		usage: "SELECT COUNT(DISTINCT l.project_id) FROM ProjectLanguage l WHERE l.language_id = e.id",
+		links: []taxonomyLink{
+			{table: "ProjectLanguage", column: "language_id", owner: "project_id"},
+			{table: "ProjectRoleSkill", column: "language_id", owner: "role_id"},
+			{table: "UserSkill", column: "language_id"},
+		},
+	},
+	domain.TaxonomyType: {
+		table: "Types",
+		usage: "SELECT COUNT(DISTINCT l.project_id) FROM ProjectType l WHERE l.type_id = e.id",
+		links: []taxonomyLink{
+			{table: "ProjectType", column: "type_id", owner: "project_id"},
+		},
+	},
+}
+
+type taxonomyRepository struct {
+	db *sqlx.DB
+}
+
+func NewTaxonomyRepository(db *sqlx.DB) TaxonomyRepository {
+	return &taxonomyRepository{
+		db: db,
+	}
+}
+
+func tableFor(kind domain.TaxonomyKind) (taxonomyTable, error) {
+	t, ok := taxonomyTables[kind]
+	if !ok {
+		return taxonomyTable{}, fmt.Errorf("unknown taxonomy kind %q", kind)
+	}
+	return t, nil
+}
+
+func (r *taxonomyRepository) List(ctx context.Context, kind domain.TaxonomyKind) ([]domain.TaxonomyEntry, error) {
+	t, err := tableFor(kind)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := []domain.TaxonomyEntry{}
+	err = r.db.SelectContext(ctx, &entries, fmt.Sprintf(`
+		SELECT e.id, e.name, e.deprecated, (%s) AS usage_count
+		FROM %s e
+		ORDER BY e.name
+	`, t.usage, t.table))
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+func (r *taxonomyRepository) GetById(ctx context.Context, kind domain.TaxonomyKind, id int) (*domain.TaxonomyEntry, error) {
+	return r.getBy(ctx, kind, "e.id = ?", id)
+}
+
+// Resolve finds the entry a user supplied name refers to, either by its own
+// name or through an alias, ignoring case and separators.
+func (r *taxonomyRepository) Resolve(ctx context.Context, kind domain.TaxonomyKind, name string) (*domain.TaxonomyEntry, error) {
+	normalized := domain.NormalizeTaxonomyName(name)
+	return r.getBy(ctx, kind, `
+		LOWER(REPLACE(REPLACE(REPLACE(REPLACE(e.name, ' ', ''), '-', ''), '_', ''), '.', '')) = ?
+		OR e.id IN (
+			SELECT a.entry_id FROM TaxonomyAlias a
+			WHERE a.kind = ? AND a.normalized = ?
+		)
+		ORDER BY e.id
+		LIMIT 1
+	`, normalized, kind, normalized)
+}
+
+func (r *taxonomyRepository) getBy(ctx context.Context, kind domain.TaxonomyKind, where string, args ...any) (*domain.TaxonomyEntry, error) {
+	t, err := tableFor(kind)
+	if err != nil {
+		return nil, err
+	}
+
+	var entry domain.TaxonomyEntry
+	err = r.db.GetContext(ctx, &entry, fmt.Sprintf(`
+		SELECT e.id, e.name, e.deprecated, (%s) AS usage_count
+		FROM %s e
+		WHERE %s
+	`, t.usage, t.table, where), args...)
+	if err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+func (r *taxonomyRepository) Create(ctx context.Context, kind domain.TaxonomyKind, name string) (int, error) {
+	t, err := tableFor(kind)
+	if err != nil {
+		return 0, err
+	}
+
+	result, err := r.db.ExecContext(ctx, fmt.Sprintf("INSERT INTO %s (name) VALUES (?)", t.table), name)
+	if err != nil {
+		return 0, mapDuplicate(err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+	return int(id), nil
+}
+
+func (r *taxonomyRepository) Update(ctx context.Context, kind domain.TaxonomyKind, id int, req *domain.UpdateTaxonomyRequest) error {
+	t, err := tableFor(kind)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.ExecContext(ctx, fmt.Sprintf("UPDATE %s SET name = ?, deprecated = ? WHERE id = ?", t.table),
+		req.Name, req.Deprecated, id)
+	return mapDuplicate(err)
+}
+
+func (r *taxonomyRepository) Merge(ctx context.Context, kind domain.TaxonomyKind, sourceId, targetId int) error {
+	t, err := tableFor(kind)
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, l := range t.links {
+		if l.owner != "" {
+			// owners already linked to the target keep their existing row
+			_, err = tx.ExecContext(ctx, fmt.Sprintf(`
+				DELETE s FROM %[1]s s
+				JOIN %[1]s d ON d.%[3]s = s.%[3]s AND d.%[2]s = ?
+				WHERE s.%[2]s = ?
+			`, l.table, l.column, l.owner), targetId, sourceId)
+			if err != nil {
+				return err
+			}
+		}
+
+		_, err = tx.ExecContext(ctx, fmt.Sprintf("UPDATE %[1]s SET %[2]s = ? WHERE %[2]s = ?", l.table, l.column),
+			targetId, sourceId)
+		if err != nil {
+			return err
+		}
+	}
+
+	// the merged entry's aliases move to the target, and its name becomes one
+	// so existing spellings keep resolving
+	var sourceName string
+	err = tx.GetContext(ctx, &sourceName, fmt.Sprintf("SELECT name FROM %s WHERE id = ?", t.table), sourceId)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT IGNORE INTO TaxonomyAlias (kind, entry_id, alias, normalized)
+		VALUES (?, ?, ?, ?)
+	`, kind, targetId, sourceName, domain.NormalizeTaxonomyName(sourceName))
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, "UPDATE IGNORE TaxonomyAlias SET entry_id = ? WHERE kind = ? AND entry_id = ?",
+		targetId, kind, sourceId)
+	if err != nil {
+		return err
+	}
+
+	// aliases the target already had are left behind by UPDATE IGNORE
+	_, err = tx.ExecContext(ctx, "DELETE FROM TaxonomyAlias WHERE kind = ? AND entry_id = ?", kind, sourceId)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, fmt.Sprintf("DELETE FROM %s WHERE id = ?", t.table), sourceId)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *taxonomyRepository) ListAliases(ctx context.Context, kind domain.TaxonomyKind, entryId int) ([]domain.TaxonomyAlias, error) {
+	aliases := []domain.TaxonomyAlias{}
+	err := r.db.SelectContext(ctx, &aliases, `
+		SELECT * FROM TaxonomyAlias
+		WHERE kind = ? AND entry_id = ?
+		ORDER BY alias
+	`, kind, entryId)
+	if err != nil {
+		return nil, err
+	}
+	return aliases, nil
+}
+
+func (r *taxonomyRepository) GetAlias(ctx context.Context, id int) (*domain.TaxonomyAlias, error) {
+	var alias domain.TaxonomyAlias
+	err := r.db.GetContext(ctx, &alias, "SELECT * FROM TaxonomyAlias WHERE id = ?", id)
+	if err != nil {
+		return nil, err
+	}
+	return &alias, nil
+}
+
+func (r *taxonomyRepository) CreateAlias(ctx context.Context, kind domain.TaxonomyKind, entryId int, alias string) (int, error) {
+	result, err := r.db.ExecContext(ctx, `
+		INSERT INTO TaxonomyAlias (kind, entry_id, alias, normalized)
+		VALUES (?, ?, ?, ?)
+	`, kind, entryId, alias, domain.NormalizeTaxonomyName(alias))
+	if err != nil {
+		return 0, mapDuplicate(err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+	return int(id), nil
+}
+
+func (r *taxonomyRepository) DeleteAlias(ctx context.Context, id int) error {
+	_, err := r.db.ExecContext(ctx, "DELETE FROM TaxonomyAlias WHERE id = ?", id)
+	return err
+}
+
+// CreateProposal adds a pending proposal. Another pending proposal with the
+// same normalized name fails with ErrTaxonomyAlreadyExists.
+func (r *taxonomyRepository) CreateProposal(ctx context.Context, name string, userId int) (int, error) {
+	result, err := r.db.ExecContext(ctx, `
+		INSERT INTO TechnologyProposal (name, user_id, status, pending_name)
+		VALUES (?, ?, ?, ?)
+	`, name, userId, domain.ProposalPending, domain.NormalizeTaxonomyName(name))
+	if err != nil {
+		return 0, mapDuplicate(err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+	return int(id), nil
+}
+
+func (r *taxonomyRepository) GetProposal(ctx context.Context, id int) (*domain.TechnologyProposal, error) {
+	var proposal domain.TechnologyProposal
+	err := r.db.GetContext(ctx, &proposal, "SELECT * FROM TechnologyProposal WHERE id = ?", id)
+	if err != nil {
+		return nil, err
+	}
+	return &proposal, nil
+}
+
+// GetPendingProposalByName finds the pending proposal whose name normalizes
+// to the same key as name.
+func (r *taxonomyRepository) GetPendingProposalByName(ctx context.Context, name string) (*domain.TechnologyProposal, error) {
+	var proposal domain.TechnologyProposal
+	err := r.db.GetContext(ctx, &proposal, `
+		SELECT * FROM TechnologyProposal
+		WHERE pending_name = ?
+	`, domain.NormalizeTaxonomyName(name))
+	if err != nil {
+		return nil, err
+	}
+	return &proposal, nil
+}
+
+func (r *taxonomyRepository) ListProposals(ctx context.Context, status string) ([]domain.TechnologyProposal, error) {
+	query := "SELECT * FROM TechnologyProposal"
+	args := []any{}
+	if status != "" {
+		query += " WHERE status = ?"
+		args = append(args, status)
+	}
+	query += " ORDER BY created_at"
+
+	proposals := []domain.TechnologyProposal{}
+	if err := r.db.SelectContext(ctx, &proposals, query, args...); err != nil {
+		return nil, err
+	}
+	return proposals, nil
+}
+
+// ReviewProposal closes a pending proposal. Approving it links the proposal
+// to the technology of the same name, creating the technology if needed.
+func (r *taxonomyRepository) ReviewProposal(ctx context.Context, id int, reviewerId int, approve bool) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var proposal domain.TechnologyProposal
+	err = tx.GetContext(ctx, &proposal, "SELECT * FROM TechnologyProposal WHERE id = ? FOR UPDATE", id)
+	if err != nil {
+		return err
+	}
+	if proposal.Status != domain.ProposalPending {
+		return domain.ErrProposalAlreadyReviewed
+	}
+
+	status := domain.ProposalRejected
+	var technologyId *int
+	if approve {
+		status = domain.ProposalApproved
+
+		_, err = tx.ExecContext(ctx, "INSERT IGNORE INTO Technology (name) VALUES (?)", proposal.Name)
+		if err != nil {
+			return err
+		}
+
+		var techId int
+		err = tx.GetContext(ctx, &techId, "SELECT id FROM Technology WHERE name = ?", proposal.Name)
+		if err != nil {
+			return err
+		}
+		technologyId = &techId
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE TechnologyProposal SET
+			status = ?,
+			technology_id = ?,
+			reviewed_by = ?,
+			reviewed_at = ?,
+			pending_name = NULL
+		WHERE id = ?
+	`, status, technologyId, reviewerId, time.Now(), id)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// mapDuplicate turns a unique key violation into ErrTaxonomyAlreadyExists.
+func mapDuplicate(err error) error {
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) && mysqlErr.Number == 1062 {
+		return domain.ErrTaxonomyAlreadyExists
+	}
+	return err
+}
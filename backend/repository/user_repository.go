@@ -30,7 +30,7 @@ func NewUserRepository(db *sqlx.DB) UserRepository {
 
 func (r *userRepository) GetUsers(ctx context.Context) ([]*domain.User, error) {
 	var users []*domain.User
-	err := r.db.Select(&users, "SELECT * FROM User")
+	err := r.db.SelectContext(ctx, &users, "SELECT * FROM User")
 	if err != nil {
 		return nil, err
 	}
@@ -40,7 +40,7 @@ func (r *userRepository) GetUsers(ctx context.Context) ([]*domain.User, error) {
 
 func (r *userRepository) GetUserById(ctx context.Context, id int) (*domain.User, error) {
 	user := domain.User{}
-	err := r.db.Get(&user, `SELECT * FROM User WHERE id = ?`, id)
+	err := r.db.GetContext(ctx, &user, `SELECT * FROM User WHERE id = ?`, id)
 	if err != nil {
 		return nil, err
 	}
@@ -50,7 +50,7 @@ func (r *userRepository) GetUserById(ctx context.Context, id int) (*domain.User,
 
 func (r *userRepository) GetUserByEmail(ctx context.Context, email string) (*domain.User, error) {
 	user := domain.User{}
-	err := r.db.Get(&user, `SELECT * FROM User WHERE email = ?`, email)
+	err := r.db.GetContext(ctx, &user, `SELECT * FROM User WHERE email = ?`, email)
 	if err != nil {
 		return nil, err
 	}
@@ -59,14 +59,14 @@ func (r *userRepository) GetUserByEmail(ctx context.Context, email string) (*dom
 }
 
 func (r *userRepository) CreateUser(ctx context.Context, user *domain.User) (*domain.User, error) {
-	tx, err := r.db.Beginx()
+	tx, err := r.db.BeginTxx(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
 	defer tx.Commit()
 
 	if user.GoogleId.Valid {
-		res, err := tx.NamedExec(`INSERT INTO User (email, google_id, name, profile_picture) VALUES (:email, :google_id, :name, :profile_picture)`, user)
+		res, err := tx.NamedExecContext(ctx, `INSERT INTO User (email, google_id, name, profile_picture) VALUES (:email, :google_id, :name, :profile_picture)`, user)
 		if err != nil {
 			tx.Rollback()
 			return nil, err
@@ -81,7 +81,7 @@ func (r *userRepository) CreateUser(ctx context.Context, user *domain.User) (*do
 		return user, nil
 	}
 
-	res, err := tx.NamedExec(`INSERT INTO User (email, password, name) VALUES (:email, :password, :name) `, user)
+	res, err := tx.NamedExecContext(ctx, `INSERT INTO User (email, password, name) VALUES (:email, :password, :name) `, user)
 	if err != nil {
 		tx.Rollback()
 		return nil, err
@@ -98,7 +98,7 @@ func (r *userRepository) CreateUser(ctx context.Context, user *domain.User) (*do
 }
 
 func (r *userRepository) UpdateUser(ctx context.Context, user *domain.User) error {
-	tx, err := r.db.Beginx()
+	tx, err := r.db.BeginTxx(ctx, nil)
 	if err != nil {
 		return err
 	}
@@ -130,7 +130,7 @@ func (r *userRepository) UpdateUser(ctx context.Context, user *domain.User) erro
 	}
 	fieldsQuery = fieldsQuery[:len(fieldsQuery)-1]
 
-	_, err = tx.NamedExec("UPDATE User SET "+fieldsQuery+" WHERE id = :id", user)
+	_, err = tx.NamedExecContext(ctx, "UPDATE User SET "+fieldsQuery+" WHERE id = :id", user)
 	if err != nil {
 		tx.Rollback()
 		return err
@@ -140,13 +140,13 @@ func (r *userRepository) UpdateUser(ctx context.Context, user *domain.User) erro
 }
 
 func (r *userRepository) DeleteUser(ctx context.Context, userId int) error {
-	tx, err := r.db.Beginx()
+	tx, err := r.db.BeginTxx(ctx, nil)
 	if err != nil {
 		return err
 	}
 	defer tx.Commit()
 
-	_, err = tx.Exec("DELETE FROM User WHERE id = ?", userId)
+	_, err = tx.ExecContext(ctx, "DELETE FROM User WHERE id = ?", userId)
 	if err != nil {
 		tx.Rollback()
 		return err
@@ -12,6 +12,7 @@ import (
 
 	"github.com/iemran93/devMatch/bootstrap"
 	"github.com/iemran93/devMatch/domain"
+	"github.com/iemran93/devMatch/internal/metrics"
 	"github.com/iemran93/devMatch/internal/tokenutil"
 	"github.com/iemran93/devMatch/repository"
 
@@ -32,10 +33,13 @@ func NewGoogleUseCase(userRepository repository.UserRepository, timeout time.Dur
 }
 
 func (lu *googleUseCase) GoogleLogin(ctx context.Context, data []byte, env *bootstrap.Env) (accessToken string, refreshToken string, err error) {
+	ctx, span := tracer.Start(ctx, "googleUseCase.GoogleLogin")
+	defer span.End()
+
 	var googleUser *domain.GoogleUser
 	err = json.Unmarshal(data, &googleUser)
 	if err != nil {
-		log.Error(err)
+		log.WithContext(ctx).Error(err)
 		return
 	}
 
@@ -52,11 +56,12 @@ func (lu *googleUseCase) GoogleLogin(ctx context.Context, data []byte, env *boot
 		if err.Error() == sql.ErrNoRows.Error() {
 			user, err = lu.userRepository.CreateUser(ctx, user)
 			if err != nil {
-				log.Error(err)
+				log.WithContext(ctx).Error(err)
 				return
 			}
+			metrics.Signups.WithLabelValues("google").Inc()
 		} else {
-			log.Error(err)
+			log.WithContext(ctx).Error(err)
 			return
 		}
 	}
@@ -68,14 +73,14 @@ func (lu *googleUseCase) GoogleLogin(ctx context.Context, data []byte, env *boot
 	// Create access token
 	accessToken, err = tokenutil.CreateAccessToken(user, env.AccessTokenSecret, env.AccessTokenExpiryHour)
 	if err != nil {
-		log.Error(err)
+		log.WithContext(ctx).Error(err)
 		return
 	}
 
 	// Create refresh token
 	refreshToken, err = tokenutil.CreateRefreshToken(user, env.RefreshTokenSecret, env.RefreshTokenExpiryHour)
 	if err != nil {
-		log.Error(err)
+		log.WithContext(ctx).Error(err)
 		return
 	}
 
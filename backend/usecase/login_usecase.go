@@ -2,6 +2,8 @@ package usecase
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"time"
 
 	"github.com/iemran93/devMatch/bootstrap"
@@ -13,6 +15,10 @@ import (
 	"golang.org/x/crypto/bcrypt"
 )
 
+// dummyPasswordHash is compared against when the email is unknown, so a
+// login takes as long whether or not the account exists.
+var dummyPasswordHash, _ = bcrypt.GenerateFromPassword([]byte("devmatch"), bcrypt.DefaultCost)
+
 type loginUseCase struct {
 	userRepository repository.UserRepository
 	contextTimeout time.Duration
@@ -26,35 +32,44 @@ func NewLoginUseCase(userRepository repository.UserRepository, timeout time.Dura
 }
 
 func (lu *loginUseCase) Login(ctx context.Context, request domain.LoginRequest, env *bootstrap.Env) (loginResponse domain.LoginResponse, err error) {
+	ctx, span := tracer.Start(ctx, "loginUseCase.Login")
+	defer span.End()
+
+	// unknown emails and wrong passwords get the same error so accounts
+	// cannot be enumerated
 	var user *domain.User
 	user, err = lu.userRepository.GetUserByEmail(ctx, request.Email)
+	if errors.Is(err, sql.ErrNoRows) {
+		bcrypt.CompareHashAndPassword(dummyPasswordHash, []byte(request.Password))
+		err = domain.ErrInvalidCredentials
+		return
+	}
 	if err != nil {
-		log.Error(err)
-		err = domain.ErrUserNotFound
+		log.WithContext(ctx).Error(err)
 		return
 	}
 
 	if user.GoogleId.Valid {
-		log.Error("User should login with Google")
+		log.WithContext(ctx).Error("User should login with Google")
 		err = domain.ErrUserShouldLoginWithGoogle
 		return
 	}
 
 	if bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(request.Password)) != nil {
-		log.Error("Invalid password")
-		err = domain.ErrInvalidPassword
+		log.WithContext(ctx).Error("Invalid password")
+		err = domain.ErrInvalidCredentials
 		return
 	}
 
 	accessToken, err := tokenutil.CreateAccessToken(user, env.AccessTokenSecret, env.AccessTokenExpiryHour)
 	if err != nil {
-		log.Error(err)
+		log.WithContext(ctx).Error(err)
 		return
 	}
 
 	refreshToken, err := tokenutil.CreateRefreshToken(user, env.RefreshTokenSecret, env.RefreshTokenExpiryHour)
 	if err != nil {
-		log.Error(err)
+		log.WithContext(ctx).Error(err)
 		return
 	}
 
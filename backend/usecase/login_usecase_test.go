@@ -0,0 +1,36 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/iemran93/devMatch/bootstrap"
+	"github.com/iemran93/devMatch/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestLogin_InvalidCredentials(t *testing.T) {
+	hash, err := bcrypt.GenerateFromPassword([]byte("secret"), bcrypt.MinCost)
+	assert.NoError(t, err)
+
+	mockRepo := new(MockUserRepository)
+	mockRepo.On("GetUserByEmail", mock.Anything, "known@example.com").Return(&domain.User{Id: 1, Email: "known@example.com", Password: string(hash)}, nil)
+	mockRepo.On("GetUserByEmail", mock.Anything, "unknown@example.com").Return(nil, sql.ErrNoRows)
+
+	lu := NewLoginUseCase(mockRepo, time.Second)
+	env := &bootstrap.Env{AccessTokenSecret: "access", RefreshTokenSecret: "refresh", AccessTokenExpiryHour: 1, RefreshTokenExpiryHour: 1}
+
+	// an unknown email and a wrong password look the same
+	_, err = lu.Login(context.Background(), domain.LoginRequest{Email: "unknown@example.com", Password: "secret"}, env)
+	assert.ErrorIs(t, err, domain.ErrInvalidCredentials)
+	_, err = lu.Login(context.Background(), domain.LoginRequest{Email: "known@example.com", Password: "wrong"}, env)
+	assert.ErrorIs(t, err, domain.ErrInvalidCredentials)
+
+	res, err := lu.Login(context.Background(), domain.LoginRequest{Email: "known@example.com", Password: "secret"}, env)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, res.AccessToken)
+}
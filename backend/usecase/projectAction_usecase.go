@@ -2,139 +2,897 @@ package usecase
 
 import (
 	"context"
+	"database/sql"
 	"errors"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/iemran93/devMatch/domain"
+	"github.com/iemran93/devMatch/internal/metrics"
 	"github.com/iemran93/devMatch/repository"
 	log "github.com/sirupsen/logrus"
 )
 
+// ProjectActionsConfig holds the tunables of the request workflow.
+type ProjectActionsConfig struct {
+	// InvitationTTL is how long an invitation can be accepted.
+	InvitationTTL time.Duration
+	// WaitlistAutoPromote moves waitlisted requests to pending when a seat
+	// reopens, instead of only telling the waiting users.
+	WaitlistAutoPromote bool
+	// MaxOpenPerUser and MaxOpenPerRole cap the pending and waitlisted
+	// applications of a user and of a role, RejectionCooldown is how long a
+	// rejected user waits before applying to the same role again. 0
+	// disables a limit.
+	MaxOpenPerUser    int
+	MaxOpenPerRole    int
+	RejectionCooldown time.Duration
+}
+
 type projectActionUseCase struct {
-	projectActionsRepository repository.ProjectActionsRepository
+	projectActionsRepository repository.ProjectActionsRepo
+	projectRolesRepository   repository.ProjectRolesInterface
 	userRepository           repository.UserRepository
 	projectRepository        repository.ProjectRepository
+	notificationRepository   repository.NotificationRepository
 	contextTimeout           time.Duration
+	config                   ProjectActionsConfig
+	now                      func() time.Time
 }
 
-func NewProjectActionsUseCase(projectActionsRepository *repository.ProjectActionsRepository, userRepository repository.UserRepository, projectRepository repository.ProjectRepository, timeout time.Duration) domain.ProjectActionsUseCase {
+func NewProjectActionsUseCase(projectActionsRepository repository.ProjectActionsRepo, projectRolesRepository repository.ProjectRolesInterface, userRepository repository.UserRepository, projectRepository repository.ProjectRepository, notificationRepository repository.NotificationRepository, timeout time.Duration, config ProjectActionsConfig) domain.ProjectActionsUseCase {
 	return &projectActionUseCase{
-		projectActionsRepository: *projectActionsRepository,
+		projectActionsRepository: projectActionsRepository,
+		projectRolesRepository:   projectRolesRepository,
 		userRepository:           userRepository,
 		projectRepository:        projectRepository,
+		notificationRepository:   notificationRepository,
 		contextTimeout:           timeout,
+		config:                   config,
+		now:                      time.Now,
 	}
 }
 
+// GetById lists the requests of a project. The owner sees all of them,
+// anyone else only their own.
 func (p *projectActionUseCase) GetById(ctx context.Context, id int) ([]*domain.ProjectRequest, error) {
 	ctx, cancel := context.WithTimeout(ctx, p.contextTimeout)
 	defer cancel()
 
-	return p.projectActionsRepository.List(ctx, id)
+	ctx, span := tracer.Start(ctx, "projectActionUseCase.GetById")
+	defer span.End()
+
+	userId := ctx.Value("user_id").(int)
+	project, err := p.projectRepository.GetById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if project == nil {
+		return nil, domain.ErrProjectNotFound
+	}
+
+	requests, err := p.projectActionsRepository.List(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if project.Creator.Id != userId {
+		own := make([]*domain.ProjectRequest, 0)
+		for _, request := range requests {
+			if request.UserId != nil && *request.UserId == userId {
+				own = append(own, request)
+			}
+		}
+		requests = own
+	}
+
+	if err := p.attachDetails(ctx, requests); err != nil {
+		return nil, err
+	}
+	return requests, nil
+}
+
+// attachDetails loads the answers and timelines of requests with one query
+// each.
+func (p *projectActionUseCase) attachDetails(ctx context.Context, requests []*domain.ProjectRequest) error {
+	ids := make([]int, len(requests))
+	byId := make(map[int]*domain.ProjectRequest, len(requests))
+	for i, request := range requests {
+		ids[i] = request.Id
+		request.Answers = []domain.RequestAnswer{}
+		request.Timeline = []domain.ProjectRequestEvent{}
+		byId[request.Id] = request
+	}
+	answers, err := p.projectActionsRepository.ListAnswers(ctx, ids)
+	if err != nil {
+		return err
+	}
+	for _, answer := range answers {
+		byId[answer.RequestId].Answers = append(byId[answer.RequestId].Answers, answer)
+	}
+	events, err := p.projectActionsRepository.ListEvents(ctx, ids)
+	if err != nil {
+		return err
+	}
+	for _, event := range events {
+		byId[event.RequestId].Timeline = append(byId[event.RequestId].Timeline, event)
+	}
+	return nil
+}
+
+// ListApplicants is the owner's view of the requests of a project with the
+// applicants' profiles, skills, reviews and match scores.
+func (p *projectActionUseCase) ListApplicants(ctx context.Context, projectId int, filter domain.ApplicantFilter) ([]*domain.Applicant, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.contextTimeout)
+	defer cancel()
+
+	ctx, span := tracer.Start(ctx, "projectActionUseCase.ListApplicants")
+	defer span.End()
+
+	project, err := p.projectRepository.GetById(ctx, projectId)
+	if err != nil {
+		return nil, err
+	}
+	if project == nil {
+		return nil, domain.ErrProjectNotFound
+	}
+	if project.Creator.Id != ctx.Value("user_id").(int) {
+		return nil, domain.ErrUserNotAllowed
+	}
+
+	requests, err := p.projectActionsRepository.List(ctx, projectId)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.attachDetails(ctx, requests); err != nil {
+		return nil, err
+	}
+
+	var requestIds, userIds []int
+	for _, request := range requests {
+		requestIds = append(requestIds, request.Id)
+		if request.UserId != nil {
+			userIds = append(userIds, *request.UserId)
+		}
+	}
+
+	profiles, err := p.projectActionsRepository.ListApplicantProfiles(ctx, userIds)
+	if err != nil {
+		return nil, err
+	}
+	profileById := make(map[int]*domain.ApplicantProfile, len(profiles))
+	for i := range profiles {
+		profileById[profiles[i].Id] = &profiles[i]
+	}
+
+	skills, err := p.projectActionsRepository.ListApplicantSkills(ctx, userIds)
+	if err != nil {
+		return nil, err
+	}
+	skillsByUser := make(map[int][]domain.ApplicantSkill)
+	for _, skill := range skills {
+		skillsByUser[skill.UserId] = append(skillsByUser[skill.UserId], skill)
+	}
+
+	reviews, err := p.projectActionsRepository.ListReviews(ctx, requestIds)
+	if err != nil {
+		return nil, err
+	}
+	reviewByRequest := make(map[int]domain.ApplicantReview, len(reviews))
+	for _, review := range reviews {
+		reviewByRequest[review.RequestId] = review
+	}
+
+	technologies := make([]int, len(project.Technologies))
+	for i, t := range project.Technologies {
+		technologies[i] = t.Id
+	}
+	languages := make([]int, len(project.Languages))
+	for i, l := range project.Languages {
+		languages[i] = l.Id
+	}
+
+	// roles that list skills are scored against them instead of the project's
+	roleById := make(map[int]*domain.ProjectRole, len(project.ProjectRoles))
+	for i := range project.ProjectRoles {
+		roleById[project.ProjectRoles[i].Id] = &project.ProjectRoles[i]
+	}
+
+	applicants := make([]*domain.Applicant, len(requests))
+	for i, request := range requests {
+		applicant := &domain.Applicant{
+			ProjectRequest: request,
+			Skills:         []domain.ApplicantSkill{},
+			Review:         reviewByRequest[request.Id],
+		}
+		if applicant.Review.Tags == nil {
+			applicant.Review.Tags = domain.StringList{}
+		}
+		if request.UserId != nil {
+			applicant.Profile = profileById[*request.UserId]
+			if userSkills, ok := skillsByUser[*request.UserId]; ok {
+				applicant.Skills = userSkills
+			}
+		}
+		if role, ok := roleById[request.RoleId]; ok && len(role.Technologies)+len(role.Languages) > 0 {
+			roleTechnologies, roleLanguages := role.SkillIds()
+			applicant.MatchScore = domain.MatchScore(roleTechnologies, roleLanguages, applicant.Skills)
+		} else {
+			applicant.MatchScore = domain.MatchScore(technologies, languages, applicant.Skills)
+		}
+		applicants[i] = applicant
+	}
+
+	return domain.FilterApplicants(applicants, filter), nil
+}
+
+// ReviewApplicant updates the owner's private review of a request.
+func (p *projectActionUseCase) ReviewApplicant(ctx context.Context, requestId int, req *domain.ReviewApplicantRequest) (*domain.ApplicantReview, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.contextTimeout)
+	defer cancel()
+
+	ctx, span := tracer.Start(ctx, "projectActionUseCase.ReviewApplicant")
+	defer span.End()
+
+	userId := ctx.Value("user_id").(int)
+	request, err := p.projectActionsRepository.GetRequestById(ctx, requestId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrRequestNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	project, err := p.projectRepository.GetById(ctx, request.ProjectId)
+	if err != nil {
+		return nil, err
+	}
+	if project == nil {
+		return nil, domain.ErrProjectNotFound
+	}
+	if project.Creator.Id != userId {
+		return nil, domain.ErrUserNotAllowed
+	}
+
+	review, err := p.projectActionsRepository.GetReview(ctx, requestId)
+	if errors.Is(err, sql.ErrNoRows) {
+		review = &domain.ApplicantReview{RequestId: requestId}
+	} else if err != nil {
+		return nil, err
+	}
+
+	review.Apply(req)
+	if err := p.projectActionsRepository.SaveReview(ctx, review, userId); err != nil {
+		return nil, err
+	}
+
+	review, err = p.projectActionsRepository.GetReview(ctx, requestId)
+	if err != nil {
+		return nil, err
+	}
+	if review.Tags == nil {
+		review.Tags = domain.StringList{}
+	}
+	return review, nil
 }
 
-func (p *projectActionUseCase) ApplyToProject(ctx context.Context, req domain.ProjectActionRequest) error {
+// ApplyToProject applies to a role. Applying to a role without free seats
+// joins its waitlist.
+func (p *projectActionUseCase) ApplyToProject(ctx context.Context, req domain.ProjectActionRequest) (string, error) {
 	ctx, cancel := context.WithTimeout(ctx, p.contextTimeout)
 	defer cancel()
 
+	ctx, span := tracer.Start(ctx, "projectActionUseCase.ApplyToProject")
+	defer span.End()
+
 	// get user from context
 	userId := ctx.Value("user_id").(int)
 	req.UserId = userId
 
-	// check if user is already applied to the project
-	requests, err := p.projectActionsRepository.GetRequstsByUserId(ctx, req.UserId)
+	if err := p.checkCooldown(ctx, req); err != nil {
+		return "", err
+	}
+	// answers must match the role's questions
+	questions, err := p.projectRolesRepository.ListQuestions(ctx, req.RoleId)
 	if err != nil {
-		return err
+		return "", err
 	}
-	for _, request := range requests {
-		if request.ProjectId == req.ProjectId && request.RoleId == req.RoleId {
-			if request.Status == "accepted" || request.Status == "pending" {
-				return domain.ErrRequestAlreadyExists
-			}
+	if err := domain.ValidateAnswers(questions, req.Answers); err != nil {
+		return "", err
+	}
+	prompts := make(map[int]string, len(questions))
+	for _, q := range questions {
+		prompts[q.Id] = q.Prompt
+	}
+	var answers []domain.RequestAnswer
+	for _, a := range req.Answers {
+		answer := strings.TrimSpace(a.Answer)
+		if answer == "" {
+			continue
 		}
+		answers = append(answers, domain.RequestAnswer{
+			QuestionId: &a.QuestionId,
+			Prompt:     prompts[a.QuestionId],
+			Answer:     answer,
+		})
 	}
-	// all checks passed, proceed to apply
-	req.Status = "pending"
-	err = p.projectActionsRepository.ApplyToProject(ctx, req)
+
+	// all checks passed, proceed to apply; the repository rejects duplicate
+	// requests, enforces the limits and decides between pending and
+	// waitlisted under the role lock
+	limits := domain.ApplyLimits{MaxOpenPerUser: p.config.MaxOpenPerUser, MaxOpenPerRole: p.config.MaxOpenPerRole}
+	status, err := p.projectActionsRepository.ApplyToProject(ctx, req, answers, limits)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", domain.ErrRoleNotFound
+	}
+	var domainErr *domain.Error
+	if errors.As(err, &domainErr) {
+		return "", err
+	}
+	if err != nil {
+		log.WithContext(ctx).Error("Failed to apply to project:", err)
+		return "", domain.ErrFaildToChangeRequestStatus
+	}
+	if status == domain.RequestWaitlisted {
+		metrics.ProjectRequests.WithLabelValues(domain.RequestWaitlisted).Inc()
+	} else {
+		metrics.ProjectRequests.WithLabelValues("applied").Inc()
+	}
+	return status, nil
+}
+
+// checkCooldown rejects applying again to a role the user was rejected for
+// within RejectionCooldown.
+func (p *projectActionUseCase) checkCooldown(ctx context.Context, req domain.ProjectActionRequest) error {
+	if p.config.RejectionCooldown <= 0 {
+		return nil
+	}
+	cooldowns, err := p.cooldowns(ctx, req.UserId)
 	if err != nil {
-		log.Error("Failed to apply to project:", err)
-		return domain.ErrFaildToChangeRequestStatus
+		return err
+	}
+	for _, c := range cooldowns {
+		if c.RoleId == req.RoleId {
+			return domain.ErrApplyCooldown.WithMessage(
+				fmt.Sprintf("you were rejected for this role recently, you can apply again after %s", c.Until.Format(time.RFC3339)))
+		}
 	}
 	return nil
 }
+
+// cooldowns returns the roles the user may not apply to again yet.
+func (p *projectActionUseCase) cooldowns(ctx context.Context, userId int) ([]domain.ApplyCooldown, error) {
+	if p.config.RejectionCooldown <= 0 {
+		return []domain.ApplyCooldown{}, nil
+	}
+	cooldowns, err := p.projectActionsRepository.ListRecentRejections(ctx, userId, p.now().Add(-p.config.RejectionCooldown))
+	if err != nil {
+		return nil, err
+	}
+	for i := range cooldowns {
+		cooldowns[i].Until = cooldowns[i].RejectedAt.Add(p.config.RejectionCooldown)
+	}
+	return cooldowns, nil
+}
+
+// ApplicationUsage reports the user's open applications and cooldowns
+// against the configured limits.
+func (p *projectActionUseCase) ApplicationUsage(ctx context.Context) (*domain.ApplicationUsage, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.contextTimeout)
+	defer cancel()
+
+	ctx, span := tracer.Start(ctx, "projectActionUseCase.ApplicationUsage")
+	defer span.End()
+
+	userId := ctx.Value("user_id").(int)
+	requests, err := p.projectActionsRepository.GetRequstsByUserId(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	cooldowns, err := p.cooldowns(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.ApplicationUsage{
+		OpenApplications:    domain.OpenApplications(requests),
+		MaxOpenApplications: p.config.MaxOpenPerUser,
+		MaxOpenPerRole:      p.config.MaxOpenPerRole,
+		Cooldowns:           cooldowns,
+	}, nil
+}
+
 func (p *projectActionUseCase) CancelRequestToProject(ctx context.Context, req domain.ProjectActionRequest) error {
 	ctx, cancel := context.WithTimeout(ctx, p.contextTimeout)
 	defer cancel()
 
+	ctx, span := tracer.Start(ctx, "projectActionUseCase.CancelRequestToProject")
+	defer span.End()
+
 	// get user from context
 	userId := ctx.Value("user_id").(int)
 	req.UserId = userId
 
-	// check if request exists
-	requests, err := p.projectActionsRepository.GetRequstsByUserId(ctx, req.UserId)
+	request, err := p.findOpenRequest(ctx, req)
 	if err != nil {
 		return err
 	}
-	for _, request := range requests {
-		if request.ProjectId == req.ProjectId && request.RoleId == req.RoleId && request.Status == "pending" {
-			// logic to cancel the request
-			return p.projectActionsRepository.CancelRequestToProject(ctx, req)
-		}
+	if request.Direction == domain.DirectionInvitation {
+		return domain.ErrInvalidTransition.WithMessage("decline the invitation instead")
+	}
+	if !domain.CanTransitionRequest(request.Status, domain.RequestCancelled) {
+		return domain.ErrInvalidTransition.WithMessage("only pending or waitlisted requests can be cancelled")
 	}
 
-	return errors.New("request not found or not in pending status")
+	if err := p.projectActionsRepository.TransitionRequest(ctx, request.Id, domain.RequestCancelled, &userId, ""); err != nil {
+		return err
+	}
+	metrics.ProjectRequests.WithLabelValues(domain.RequestCancelled).Inc()
+	return nil
 }
 func (p *projectActionUseCase) WithdrawFromProject(ctx context.Context, req domain.ProjectActionRequest) error {
 	ctx, cancel := context.WithTimeout(ctx, p.contextTimeout)
 	defer cancel()
 
+	ctx, span := tracer.Start(ctx, "projectActionUseCase.WithdrawFromProject")
+	defer span.End()
+
 	// get user from context
 	userId := ctx.Value("user_id").(int)
 	req.UserId = userId
 
-	// check if request exists
-	requests, err := p.projectActionsRepository.GetRequstsByUserId(ctx, req.UserId)
+	request, err := p.findOpenRequest(ctx, req)
 	if err != nil {
 		return err
 	}
-	for _, request := range requests {
-		if request.ProjectId == req.ProjectId && request.RoleId == req.RoleId && (request.Status == "accepted") {
-			// logic to withdraw from the project
-			return p.projectActionsRepository.WithdrawFromProject(ctx, req)
+	if !domain.CanTransitionRequest(request.Status, domain.RequestWithdrawn) {
+		return domain.ErrInvalidTransition.WithMessage("only accepted requests can be withdrawn")
+	}
+
+	if err := p.projectActionsRepository.TransitionRequest(ctx, request.Id, domain.RequestWithdrawn, &userId, ""); err != nil {
+		return err
+	}
+	// the role is open again
+	repository.InvalidateProject(p.projectRepository, req.ProjectId)
+	metrics.ProjectRequests.WithLabelValues(domain.RequestWithdrawn).Inc()
+	p.reopenWaitlist(ctx, request.ProjectId, request.RoleId)
+	return nil
+}
+
+// RemoveMember lets the project creator, the only one managing it, remove an
+// accepted member. The role opens again and the member is notified with the
+// reason.
+func (p *projectActionUseCase) RemoveMember(ctx context.Context, requestId int, req *domain.RemoveMemberRequest) error {
+	ctx, cancel := context.WithTimeout(ctx, p.contextTimeout)
+	defer cancel()
+
+	ctx, span := tracer.Start(ctx, "projectActionUseCase.RemoveMember")
+	defer span.End()
+
+	ownerId := ctx.Value("user_id").(int)
+	request, err := p.projectActionsRepository.GetRequestById(ctx, requestId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.ErrRequestNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	project, err := p.projectRepository.GetById(ctx, request.ProjectId)
+	if err != nil {
+		return err
+	}
+	if project == nil {
+		return domain.ErrProjectNotFound
+	}
+	if project.Creator.Id != ownerId {
+		return domain.ErrUserNotAllowed
+	}
+	if !domain.CanTransitionRequest(request.Status, domain.RequestRemoved) {
+		return domain.ErrInvalidTransition.WithMessage("only accepted members can be removed")
+	}
+
+	reason := strings.TrimSpace(req.Reason)
+	if err := p.projectActionsRepository.TransitionRequest(ctx, requestId, domain.RequestRemoved, &ownerId, reason); err != nil {
+		return err
+	}
+	// the role is open again
+	repository.InvalidateProject(p.projectRepository, request.ProjectId)
+	metrics.ProjectRequests.WithLabelValues(domain.RequestRemoved).Inc()
+	p.reopenWaitlist(ctx, request.ProjectId, request.RoleId)
+
+	content := fmt.Sprintf("You were removed from %s", project.Title)
+	if reason != "" {
+		content += ": " + reason
+	}
+	err = p.notificationRepository.Create(ctx, &domain.Notification{
+		UserId:    *request.UserId,
+		ActorId:   &ownerId,
+		ProjectId: &request.ProjectId,
+		Type:      domain.NotificationMemberRemoved,
+		Content:   content,
+		CreatedAt: p.now(),
+	})
+	if err != nil {
+		// the member is removed either way
+		log.WithContext(ctx).Error("Failed to notify removed member: ", err)
+	}
+	return nil
+}
+
+// reopenWaitlist offers a seat that was just freed to the role's waitlist.
+// With WaitlistAutoPromote the first users in line move to pending review,
+// otherwise every waiting user is told in line order. Failures are only
+// logged: the seat is free either way and owners can still accept from the
+// waitlist.
+func (p *projectActionUseCase) reopenWaitlist(ctx context.Context, projectId int, roleId int) {
+	waitlist, err := p.projectActionsRepository.ReopenWaitlist(ctx, roleId, p.config.WaitlistAutoPromote)
+	if err != nil {
+		log.WithContext(ctx).Error("Failed to reopen waitlist: ", err)
+		return
+	}
+	if len(waitlist) == 0 {
+		return
+	}
+
+	role, err := p.projectRolesRepository.Get(ctx, roleId)
+	if err != nil {
+		log.WithContext(ctx).Error("Failed to load role for waitlist notifications: ", err)
+		return
+	}
+	project, err := p.projectRepository.GetById(ctx, projectId)
+	if err == nil && project == nil {
+		err = domain.ErrProjectNotFound
+	}
+	if err != nil {
+		log.WithContext(ctx).Error("Failed to load project for waitlist notifications: ", err)
+		return
+	}
+
+	for i, request := range waitlist {
+		if request.UserId == nil {
+			continue
+		}
+		notification := &domain.Notification{
+			UserId:    *request.UserId,
+			ProjectId: &projectId,
+			CreatedAt: p.now(),
+		}
+		if p.config.WaitlistAutoPromote {
+			metrics.ProjectRequests.WithLabelValues("promoted").Inc()
+			notification.Type = domain.NotificationWaitlistPromoted
+			notification.Content = fmt.Sprintf("A seat opened for %s in %s, your request moved from the waitlist to review", role.Title, project.Title)
+		} else {
+			notification.Type = domain.NotificationSeatReopened
+			notification.Content = fmt.Sprintf("A seat opened for %s in %s, you are number %d on the waitlist", role.Title, project.Title, i+1)
+		}
+		if err := p.notificationRepository.Create(ctx, notification); err != nil {
+			log.WithContext(ctx).Error("Failed to notify waitlisted user: ", err)
 		}
 	}
+}
 
-	return errors.New("request not found or not in accepted/pending status")
+// findOpenRequest returns the user's pending, waitlisted or accepted
+// request for the role. Finished requests stay as history and are ignored.
+func (p *projectActionUseCase) findOpenRequest(ctx context.Context, req domain.ProjectActionRequest) (*domain.ProjectRequest, error) {
+	requests, err := p.projectActionsRepository.GetRequstsByUserId(ctx, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	for _, request := range requests {
+		if request.ProjectId == req.ProjectId && request.RoleId == req.RoleId && domain.IsOpenRequest(request.Status) {
+			return &request, nil
+		}
+	}
+	return nil, domain.ErrRequestNotFound
 }
 
 func (p *projectActionUseCase) ReplyToRequest(ctx context.Context, req domain.ProjectActionReplyRequest) error {
 	ctx, cancel := context.WithTimeout(ctx, p.contextTimeout)
 	defer cancel()
 
+	ctx, span := tracer.Start(ctx, "projectActionUseCase.ReplyToRequest")
+	defer span.End()
+
 	// get user from context
 	userID := ctx.Value("user_id").(int)
 
 	// check if request exists and project belong to the user (owner)
 	request, err := p.projectActionsRepository.GetRequestById(ctx, req.RequestId)
-	if err != nil {
+	if errors.Is(err, sql.ErrNoRows) {
 		return domain.ErrRequestNotFound
 	}
+	if err != nil {
+		return err
+	}
 
 	project, err := p.projectRepository.GetById(ctx, request.ProjectId)
 	if err != nil {
+		return err
+	}
+	if project == nil {
 		return domain.ErrProjectNotFound
 	}
 	if project.Creator.Id != userID {
 		return domain.ErrUserNotAllowed
 	}
+	if request.Direction == domain.DirectionInvitation {
+		return domain.ErrRequestNorAllowed.WithMessage("invitations are answered by the invitee")
+	}
 
-	// check if project role is not filled
-	for _, pr := range project.ProjectRoles {
-		if pr.Id == request.RoleId && pr.IsFilled {
-			return domain.ErrRequestNorAllowed
+	// the repository checks the request is pending and the role still open
+	// under a row lock, a check against the cached project could be stale
+	autoRejected, err := p.projectActionsRepository.ReplyToRequest(ctx, req, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.ErrRequestNotFound
+	}
+	if err != nil {
+		return err
+	}
+	repository.InvalidateProject(p.projectRepository, request.ProjectId)
+
+	if req.Accepted {
+		metrics.ProjectRequests.WithLabelValues(domain.RequestAccepted).Inc()
+		metrics.ProjectRequests.WithLabelValues(domain.RequestRejected).Add(float64(autoRejected))
+	} else {
+		metrics.ProjectRequests.WithLabelValues(domain.RequestRejected).Inc()
+	}
+	return nil
+}
+
+func (p *projectActionUseCase) Invite(ctx context.Context, req *domain.InviteRequest) (*domain.ProjectRequest, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.contextTimeout)
+	defer cancel()
+
+	ctx, span := tracer.Start(ctx, "projectActionUseCase.Invite")
+	defer span.End()
+
+	ownerId := ctx.Value("user_id").(int)
+	project, err := p.projectRepository.GetById(ctx, req.ProjectId)
+	if err != nil {
+		return nil, err
+	}
+	if project == nil {
+		return nil, domain.ErrProjectNotFound
+	}
+	if project.Creator.Id != ownerId {
+		return nil, domain.ErrUserNotAllowed
+	}
+
+	role, err := p.projectRolesRepository.Get(ctx, req.RoleId)
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && role.ProjectId != req.ProjectId) {
+		return nil, domain.ErrRoleNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if role.SeatsRemaining <= 0 {
+		return nil, domain.ErrRequestNorAllowed.WithMessage("role has no seats left")
+	}
+
+	// resolve the invitee, an email of a registered user invites that user
+	// inviteeEmail also finds invitations sent to the invitee's email
+	// before they signed up
+	var inviteeId *int
+	var inviteeEmail string
+	if req.UserId != 0 {
+		user, err := p.userRepository.GetUserById(ctx, req.UserId)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrUserNotFound
+		}
+		if err != nil {
+			return nil, err
+		}
+		inviteeId = &user.Id
+		inviteeEmail = strings.ToLower(user.Email)
+		req.Email = ""
+	} else {
+		req.Email = strings.ToLower(strings.TrimSpace(req.Email))
+		inviteeEmail = req.Email
+		user, err := p.userRepository.GetUserByEmail(ctx, req.Email)
+		if err == nil {
+			inviteeId = &user.Id
+		} else if !errors.Is(err, sql.ErrNoRows) {
+			return nil, err
+		}
+	}
+	if inviteeId != nil && *inviteeId == ownerId {
+		return nil, domain.ErrRequestNorAllowed.WithMessage("cannot invite yourself")
+	}
+
+	exists, err := p.projectActionsRepository.HasOpenRequest(ctx, req.RoleId, inviteeId, inviteeEmail)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, domain.ErrRequestAlreadyExists
+	}
+
+	id, err := p.projectActionsRepository.CreateInvitation(ctx, req, inviteeId, ownerId, p.now().Add(p.config.InvitationTTL))
+	if err != nil {
+		return nil, err
+	}
+	metrics.ProjectRequests.WithLabelValues("invited").Inc()
+
+	// invitees without an account find the invitation once they sign up
+	if inviteeId != nil {
+		err = p.notificationRepository.Create(ctx, &domain.Notification{
+			UserId:    *inviteeId,
+			ActorId:   &ownerId,
+			ProjectId: &req.ProjectId,
+			Type:      domain.NotificationInvitationReceived,
+			Content:   fmt.Sprintf("You were invited to join %s as %s", project.Title, role.Title),
+			CreatedAt: p.now(),
+		})
+		if err != nil {
+			// the invitation is listed either way
+			log.WithContext(ctx).Error("Failed to notify invitee: ", err)
 		}
 	}
+	return p.projectActionsRepository.GetRequestById(ctx, id)
+}
 
-	// logic to reply to the request
-	return p.projectActionsRepository.ReplyToRequest(ctx, req)
+func (p *projectActionUseCase) ListInvitations(ctx context.Context) ([]*domain.ProjectRequest, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.contextTimeout)
+	defer cancel()
+
+	ctx, span := tracer.Start(ctx, "projectActionUseCase.ListInvitations")
+	defer span.End()
+
+	user, err := p.userRepository.GetUserById(ctx, ctx.Value("user_id").(int))
+	if err != nil {
+		return nil, err
+	}
+	return p.projectActionsRepository.ListInvitations(ctx, user.Id, strings.ToLower(user.Email))
+}
+
+func (p *projectActionUseCase) RespondToInvitation(ctx context.Context, id int, accept bool) error {
+	ctx, cancel := context.WithTimeout(ctx, p.contextTimeout)
+	defer cancel()
+
+	ctx, span := tracer.Start(ctx, "projectActionUseCase.RespondToInvitation")
+	defer span.End()
+
+	userId := ctx.Value("user_id").(int)
+	user, err := p.userRepository.GetUserById(ctx, userId)
+	if err != nil {
+		return err
+	}
+
+	request, err := p.projectActionsRepository.GetRequestById(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.ErrRequestNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if !isInvitee(request, user) {
+		return domain.ErrRequestNotFound
+	}
+
+	if request.Status == domain.RequestPending && request.ExpiresAt != nil && p.now().After(*request.ExpiresAt) {
+		err := p.projectActionsRepository.TransitionRequest(ctx, id, domain.RequestExpired, nil, "invitation expired")
+		if err != nil && !errors.Is(err, domain.ErrInvalidTransition) {
+			return err
+		}
+		return domain.ErrInvitationExpired
+	}
+
+	if !accept {
+		if err := p.projectActionsRepository.TransitionRequest(ctx, id, domain.RequestRejected, &userId, "declined"); err != nil {
+			return err
+		}
+		metrics.ProjectRequests.WithLabelValues("declined").Inc()
+		p.notifyInviter(ctx, request, user, false)
+		return nil
+	}
+
+	autoRejected, err := p.projectActionsRepository.AcceptInvitation(ctx, id, userId)
+	if err != nil {
+		return err
+	}
+	repository.InvalidateProject(p.projectRepository, request.ProjectId)
+	metrics.ProjectRequests.WithLabelValues(domain.RequestAccepted).Inc()
+	metrics.ProjectRequests.WithLabelValues(domain.RequestRejected).Add(float64(autoRejected))
+	p.notifyInviter(ctx, request, user, true)
+	return nil
+}
+
+// notifyInviter tells the owner who sent an invitation that the invitee
+// answered it. Failures are only logged, the answer stands either way.
+func (p *projectActionUseCase) notifyInviter(ctx context.Context, request *domain.ProjectRequest, invitee *domain.User, accepted bool) {
+	if request.InvitedBy == nil {
+		return
+	}
+	project, err := p.projectRepository.GetById(ctx, request.ProjectId)
+	if err == nil && project == nil {
+		err = domain.ErrProjectNotFound
+	}
+	if err != nil {
+		log.WithContext(ctx).Error("Failed to load project for invitation notification: ", err)
+		return
+	}
+
+	notification := &domain.Notification{
+		UserId:    *request.InvitedBy,
+		ActorId:   &invitee.Id,
+		ProjectId: &request.ProjectId,
+		Type:      domain.NotificationInvitationDeclined,
+		Content:   fmt.Sprintf("%s declined your invitation to %s", invitee.Name, project.Title),
+		CreatedAt: p.now(),
+	}
+	if accepted {
+		notification.Type = domain.NotificationInvitationAccepted
+		notification.Content = fmt.Sprintf("%s accepted your invitation to %s", invitee.Name, project.Title)
+	}
+	if err := p.notificationRepository.Create(ctx, notification); err != nil {
+		log.WithContext(ctx).Error("Failed to notify inviter: ", err)
+	}
+}
+
+func (p *projectActionUseCase) CancelInvitation(ctx context.Context, id int) error {
+	ctx, cancel := context.WithTimeout(ctx, p.contextTimeout)
+	defer cancel()
+
+	ctx, span := tracer.Start(ctx, "projectActionUseCase.CancelInvitation")
+	defer span.End()
+
+	userId := ctx.Value("user_id").(int)
+	request, err := p.projectActionsRepository.GetRequestById(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.ErrRequestNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if request.Direction != domain.DirectionInvitation {
+		return domain.ErrRequestNotFound
+	}
+
+	project, err := p.projectRepository.GetById(ctx, request.ProjectId)
+	if err != nil {
+		return err
+	}
+	if project == nil {
+		return domain.ErrProjectNotFound
+	}
+	if project.Creator.Id != userId {
+		return domain.ErrUserNotAllowed
+	}
+	if !domain.CanTransitionRequest(request.Status, domain.RequestCancelled) {
+		return domain.ErrInvalidTransition.WithMessage("only pending invitations can be cancelled")
+	}
+
+	if err := p.projectActionsRepository.TransitionRequest(ctx, id, domain.RequestCancelled, &userId, ""); err != nil {
+		return err
+	}
+	metrics.ProjectRequests.WithLabelValues(domain.RequestCancelled).Inc()
+
+	if request.UserId != nil {
+		err = p.notificationRepository.Create(ctx, &domain.Notification{
+			UserId:    *request.UserId,
+			ActorId:   &userId,
+			ProjectId: &request.ProjectId,
+			Type:      domain.NotificationInvitationCancelled,
+			Content:   fmt.Sprintf("Your invitation to %s was withdrawn", project.Title),
+			CreatedAt: p.now(),
+		})
+		if err != nil {
+			// the invitation is cancelled either way
+			log.WithContext(ctx).Error("Failed to notify invitee: ", err)
+		}
+	}
+	return nil
+}
+
+// isInvitee reports whether the invitation is addressed to user, directly or
+// through the email it was sent to.
+func isInvitee(request *domain.ProjectRequest, user *domain.User) bool {
+	if request.Direction != domain.DirectionInvitation {
+		return false
+	}
+	if request.UserId != nil {
+		return *request.UserId == user.Id
+	}
+	return request.InviteeEmail != nil && strings.EqualFold(*request.InviteeEmail, user.Email)
 }
@@ -0,0 +1,494 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/iemran93/devMatch/domain"
+	"github.com/iemran93/devMatch/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func (m *MockProjectActionsRepo) GetRequstsByUserId(ctx context.Context, userId int) ([]domain.ProjectRequest, error) {
+	args := m.Called(ctx, userId)
+	requests, _ := args.Get(0).([]domain.ProjectRequest)
+	return requests, args.Error(1)
+}
+
+func (m *MockProjectActionsRepo) ReopenWaitlist(ctx context.Context, roleId int, promote bool) ([]domain.ProjectRequest, error) {
+	args := m.Called(ctx, roleId, promote)
+	requests, _ := args.Get(0).([]domain.ProjectRequest)
+	return requests, args.Error(1)
+}
+
+func (m *MockProjectActionsRepo) GetRequestById(ctx context.Context, requestId int) (*domain.ProjectRequest, error) {
+	args := m.Called(ctx, requestId)
+	request, _ := args.Get(0).(*domain.ProjectRequest)
+	return request, args.Error(1)
+}
+
+func (m *MockProjectActionsRepo) HasOpenRequest(ctx context.Context, roleId int, userId *int, email string) (bool, error) {
+	args := m.Called(ctx, roleId, userId, email)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockProjectActionsRepo) CreateInvitation(ctx context.Context, req *domain.InviteRequest, userId *int, invitedBy int, expiresAt time.Time) (int, error) {
+	args := m.Called(ctx, req, userId, invitedBy, expiresAt)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockProjectActionsRepo) AcceptInvitation(ctx context.Context, requestId int, userId int) (int64, error) {
+	args := m.Called(ctx, requestId, userId)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+// MockProjectRepository mocks GetById; calling any other method panics on
+// the nil embedded interface.
+type MockProjectRepository struct {
+	mock.Mock
+	repository.ProjectRepository
+}
+
+func (m *MockProjectRepository) GetById(ctx context.Context, id int) (*domain.ProjectResponse, error) {
+	args := m.Called(ctx, id)
+	project, _ := args.Get(0).(*domain.ProjectResponse)
+	return project, args.Error(1)
+}
+
+// MockProjectRolesRepository mocks Get; calling any other method panics on
+// the nil embedded interface.
+type MockProjectRolesRepository struct {
+	mock.Mock
+	repository.ProjectRolesInterface
+}
+
+func (m *MockProjectRolesRepository) Get(ctx context.Context, id int) (*domain.ProjectRole, error) {
+	args := m.Called(ctx, id)
+	role, _ := args.Get(0).(*domain.ProjectRole)
+	return role, args.Error(1)
+}
+
+// openRequest is the user's request for role 9 of project 5.
+func openRequest(userId int, direction string, status string) domain.ProjectRequest {
+	return domain.ProjectRequest{Id: 1, ProjectId: 5, RoleId: 9, UserId: &userId, Direction: direction, Status: status}
+}
+
+func newProjectActionsUseCase(repo *MockProjectActionsRepo) domain.ProjectActionsUseCase {
+	return NewProjectActionsUseCase(repo, nil, nil, nil, nil, time.Second*5, ProjectActionsConfig{})
+}
+
+func TestCancelRequestToProject(t *testing.T) {
+	userId := 7
+	mockRepo := new(MockProjectActionsRepo)
+	mockRepo.On("GetRequstsByUserId", mock.Anything, userId).Return([]domain.ProjectRequest{
+		// a finished request for the same role is history and ignored
+		{Id: 2, ProjectId: 5, RoleId: 9, UserId: &userId, Direction: domain.DirectionApplication, Status: domain.RequestRejected},
+		openRequest(userId, domain.DirectionApplication, domain.RequestWaitlisted),
+	}, nil)
+	mockRepo.On("TransitionRequest", mock.Anything, 1, domain.RequestCancelled, &userId, "").Return(nil)
+
+	pu := newProjectActionsUseCase(mockRepo)
+	ctx := context.WithValue(context.Background(), "user_id", userId)
+
+	err := pu.CancelRequestToProject(ctx, domain.ProjectActionRequest{ProjectId: 5, RoleId: 9})
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestCancelRequestToProject_InvalidTransition(t *testing.T) {
+	userId := 7
+	tests := []struct {
+		name    string
+		request domain.ProjectRequest
+	}{
+		{"accepted", openRequest(userId, domain.DirectionApplication, domain.RequestAccepted)},
+		{"invitation", openRequest(userId, domain.DirectionInvitation, domain.RequestPending)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockProjectActionsRepo)
+			mockRepo.On("GetRequstsByUserId", mock.Anything, userId).Return([]domain.ProjectRequest{tt.request}, nil)
+
+			pu := newProjectActionsUseCase(mockRepo)
+			ctx := context.WithValue(context.Background(), "user_id", userId)
+
+			err := pu.CancelRequestToProject(ctx, domain.ProjectActionRequest{ProjectId: 5, RoleId: 9})
+
+			assert.ErrorIs(t, err, domain.ErrInvalidTransition)
+			mockRepo.AssertNotCalled(t, "TransitionRequest", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestCancelRequestToProject_AnsweredConcurrently(t *testing.T) {
+	userId := 7
+	mockRepo := new(MockProjectActionsRepo)
+	mockRepo.On("GetRequstsByUserId", mock.Anything, userId).Return([]domain.ProjectRequest{
+		openRequest(userId, domain.DirectionApplication, domain.RequestPending),
+	}, nil)
+	// the owner accepted it after it was read
+	mockRepo.On("TransitionRequest", mock.Anything, 1, domain.RequestCancelled, &userId, "").Return(domain.ErrInvalidTransition)
+
+	pu := newProjectActionsUseCase(mockRepo)
+	ctx := context.WithValue(context.Background(), "user_id", userId)
+
+	err := pu.CancelRequestToProject(ctx, domain.ProjectActionRequest{ProjectId: 5, RoleId: 9})
+
+	assert.ErrorIs(t, err, domain.ErrInvalidTransition)
+}
+
+func TestCancelRequestToProject_NotFound(t *testing.T) {
+	userId := 7
+	mockRepo := new(MockProjectActionsRepo)
+	mockRepo.On("GetRequstsByUserId", mock.Anything, userId).Return([]domain.ProjectRequest{
+		openRequest(userId, domain.DirectionApplication, domain.RequestCancelled),
+	}, nil)
+
+	pu := newProjectActionsUseCase(mockRepo)
+	ctx := context.WithValue(context.Background(), "user_id", userId)
+
+	err := pu.CancelRequestToProject(ctx, domain.ProjectActionRequest{ProjectId: 5, RoleId: 9})
+
+	assert.ErrorIs(t, err, domain.ErrRequestNotFound)
+	mockRepo.AssertNotCalled(t, "TransitionRequest", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestWithdrawFromProject(t *testing.T) {
+	userId := 7
+	mockRepo := new(MockProjectActionsRepo)
+	mockRepo.On("GetRequstsByUserId", mock.Anything, userId).Return([]domain.ProjectRequest{
+		openRequest(userId, domain.DirectionApplication, domain.RequestAccepted),
+	}, nil)
+	mockRepo.On("TransitionRequest", mock.Anything, 1, domain.RequestWithdrawn, &userId, "").Return(nil)
+	// the freed seat is offered to the waitlist
+	mockRepo.On("ReopenWaitlist", mock.Anything, 9, false).Return([]domain.ProjectRequest{}, nil)
+
+	pu := newProjectActionsUseCase(mockRepo)
+	ctx := context.WithValue(context.Background(), "user_id", userId)
+
+	err := pu.WithdrawFromProject(ctx, domain.ProjectActionRequest{ProjectId: 5, RoleId: 9})
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestWithdrawFromProject_InvalidTransition(t *testing.T) {
+	userId := 7
+	mockRepo := new(MockProjectActionsRepo)
+	mockRepo.On("GetRequstsByUserId", mock.Anything, userId).Return([]domain.ProjectRequest{
+		openRequest(userId, domain.DirectionApplication, domain.RequestPending),
+	}, nil)
+
+	pu := newProjectActionsUseCase(mockRepo)
+	ctx := context.WithValue(context.Background(), "user_id", userId)
+
+	err := pu.WithdrawFromProject(ctx, domain.ProjectActionRequest{ProjectId: 5, RoleId: 9})
+
+	assert.ErrorIs(t, err, domain.ErrInvalidTransition)
+	mockRepo.AssertNotCalled(t, "TransitionRequest", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	mockRepo.AssertNotCalled(t, "ReopenWaitlist", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// projectActionMocks is project 5 owned by user 3 with role 9, which has a
+// free seat.
+type projectActionMocks struct {
+	requests      *MockProjectActionsRepo
+	projects      *MockProjectRepository
+	roles         *MockProjectRolesRepository
+	users         *MockUserRepository
+	notifications *MockNotificationRepository
+}
+
+func newProjectActionMocks() *projectActionMocks {
+	m := &projectActionMocks{
+		requests:      new(MockProjectActionsRepo),
+		projects:      new(MockProjectRepository),
+		roles:         new(MockProjectRolesRepository),
+		users:         new(MockUserRepository),
+		notifications: new(MockNotificationRepository),
+	}
+	m.projects.On("GetById", mock.Anything, 5).Return(&domain.ProjectResponse{Id: 5, Title: "devMatch", Creator: domain.UserResponse{Id: 3}}, nil)
+	m.roles.On("Get", mock.Anything, 9).Return(&domain.ProjectRole{Id: 9, ProjectId: 5, Title: "Backend", SeatsRemaining: 1}, nil)
+	return m
+}
+
+func (m *projectActionMocks) useCase() domain.ProjectActionsUseCase {
+	return NewProjectActionsUseCase(m.requests, m.roles, m.users, m.projects, m.notifications, time.Second*5, ProjectActionsConfig{InvitationTTL: 7 * 24 * time.Hour})
+}
+
+// invitation is a pending invitation of owner 3 for role 9.
+func invitation(userId *int, email *string) *domain.ProjectRequest {
+	owner := 3
+	return &domain.ProjectRequest{Id: 11, ProjectId: 5, RoleId: 9, UserId: userId, InviteeEmail: email, InvitedBy: &owner, Direction: domain.DirectionInvitation, Status: domain.RequestPending}
+}
+
+func TestInvite_NotOwner(t *testing.T) {
+	m := newProjectActionMocks()
+	ctx := context.WithValue(context.Background(), "user_id", 4)
+
+	request, err := m.useCase().Invite(ctx, &domain.InviteRequest{ProjectId: 5, RoleId: 9, UserId: 8})
+
+	assert.ErrorIs(t, err, domain.ErrUserNotAllowed)
+	assert.Nil(t, request)
+	m.requests.AssertNotCalled(t, "CreateInvitation", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	m.notifications.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestInvite_ByUserId(t *testing.T) {
+	invitee := 8
+	m := newProjectActionMocks()
+	m.users.On("GetUserById", mock.Anything, invitee).Return(&domain.User{Id: invitee, Email: "Dev@Example.com"}, nil)
+	// invitations sent to the email before the invitee signed up count too
+	m.requests.On("HasOpenRequest", mock.Anything, 9, &invitee, "dev@example.com").Return(false, nil)
+	m.requests.On("CreateInvitation", mock.Anything, mock.MatchedBy(func(req *domain.InviteRequest) bool {
+		return req.Email == ""
+	}), &invitee, 3, mock.Anything).Return(11, nil)
+	m.requests.On("GetRequestById", mock.Anything, 11).Return(invitation(&invitee, nil), nil)
+	m.notifications.On("Create", mock.Anything, mock.MatchedBy(func(n *domain.Notification) bool {
+		return n.UserId == invitee && *n.ActorId == 3 && *n.ProjectId == 5 && n.Type == domain.NotificationInvitationReceived &&
+			n.Content == "You were invited to join devMatch as Backend"
+	})).Return(nil)
+	ctx := context.WithValue(context.Background(), "user_id", 3)
+
+	request, err := m.useCase().Invite(ctx, &domain.InviteRequest{ProjectId: 5, RoleId: 9, UserId: invitee, Email: "ignored@example.com"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 11, request.Id)
+	m.requests.AssertExpectations(t)
+	m.notifications.AssertExpectations(t)
+}
+
+func TestInvite_ByEmail(t *testing.T) {
+	t.Run("registered", func(t *testing.T) {
+		invitee := 8
+		m := newProjectActionMocks()
+		m.users.On("GetUserByEmail", mock.Anything, "dev@example.com").Return(&domain.User{Id: invitee, Email: "dev@example.com"}, nil)
+		m.requests.On("HasOpenRequest", mock.Anything, 9, &invitee, "dev@example.com").Return(false, nil)
+		m.requests.On("CreateInvitation", mock.Anything, mock.Anything, &invitee, 3, mock.Anything).Return(11, nil)
+		m.requests.On("GetRequestById", mock.Anything, 11).Return(invitation(&invitee, nil), nil)
+		m.notifications.On("Create", mock.Anything, mock.MatchedBy(func(n *domain.Notification) bool {
+			return n.UserId == invitee && n.Type == domain.NotificationInvitationReceived
+		})).Return(nil)
+		ctx := context.WithValue(context.Background(), "user_id", 3)
+
+		_, err := m.useCase().Invite(ctx, &domain.InviteRequest{ProjectId: 5, RoleId: 9, Email: " Dev@Example.com "})
+
+		assert.NoError(t, err)
+		m.requests.AssertExpectations(t)
+		m.notifications.AssertExpectations(t)
+	})
+
+	t.Run("not registered", func(t *testing.T) {
+		email := "new@example.com"
+		m := newProjectActionMocks()
+		m.users.On("GetUserByEmail", mock.Anything, email).Return(nil, sql.ErrNoRows)
+		m.requests.On("HasOpenRequest", mock.Anything, 9, (*int)(nil), email).Return(false, nil)
+		m.requests.On("CreateInvitation", mock.Anything, mock.MatchedBy(func(req *domain.InviteRequest) bool {
+			return req.Email == email
+		}), (*int)(nil), 3, mock.Anything).Return(11, nil)
+		m.requests.On("GetRequestById", mock.Anything, 11).Return(invitation(nil, &email), nil)
+		ctx := context.WithValue(context.Background(), "user_id", 3)
+
+		_, err := m.useCase().Invite(ctx, &domain.InviteRequest{ProjectId: 5, RoleId: 9, Email: email})
+
+		assert.NoError(t, err)
+		m.requests.AssertExpectations(t)
+		// there is no account to notify yet
+		m.notifications.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
+
+func TestRespondToInvitation_Accept(t *testing.T) {
+	invitee := 8
+	m := newProjectActionMocks()
+	m.users.On("GetUserById", mock.Anything, invitee).Return(&domain.User{Id: invitee, Name: "Dev", Email: "dev@example.com"}, nil)
+	// sent to the email before the invitee signed up
+	email := "dev@example.com"
+	m.requests.On("GetRequestById", mock.Anything, 11).Return(invitation(nil, &email), nil)
+	m.requests.On("AcceptInvitation", mock.Anything, 11, invitee).Return(int64(0), nil)
+	m.notifications.On("Create", mock.Anything, mock.MatchedBy(func(n *domain.Notification) bool {
+		return n.UserId == 3 && *n.ActorId == invitee && n.Type == domain.NotificationInvitationAccepted &&
+			n.Content == "Dev accepted your invitation to devMatch"
+	})).Return(nil)
+	ctx := context.WithValue(context.Background(), "user_id", invitee)
+
+	err := m.useCase().RespondToInvitation(ctx, 11, true)
+
+	assert.NoError(t, err)
+	m.requests.AssertExpectations(t)
+	m.notifications.AssertExpectations(t)
+}
+
+func TestRespondToInvitation_AcceptFullRole(t *testing.T) {
+	invitee := 8
+	m := newProjectActionMocks()
+	m.users.On("GetUserById", mock.Anything, invitee).Return(&domain.User{Id: invitee, Email: "dev@example.com"}, nil)
+	m.requests.On("GetRequestById", mock.Anything, 11).Return(invitation(&invitee, nil), nil)
+	// the last seat was taken after the invitation was sent
+	m.requests.On("AcceptInvitation", mock.Anything, 11, invitee).Return(int64(0), domain.ErrRequestNorAllowed.WithMessage("role has no seats left"))
+	ctx := context.WithValue(context.Background(), "user_id", invitee)
+
+	err := m.useCase().RespondToInvitation(ctx, 11, true)
+
+	assert.ErrorIs(t, err, domain.ErrRequestNorAllowed)
+	m.notifications.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestRespondToInvitation_Decline(t *testing.T) {
+	invitee := 8
+	m := newProjectActionMocks()
+	m.users.On("GetUserById", mock.Anything, invitee).Return(&domain.User{Id: invitee, Name: "Dev", Email: "dev@example.com"}, nil)
+	m.requests.On("GetRequestById", mock.Anything, 11).Return(invitation(&invitee, nil), nil)
+	m.requests.On("TransitionRequest", mock.Anything, 11, domain.RequestRejected, &invitee, "declined").Return(nil)
+	m.notifications.On("Create", mock.Anything, mock.MatchedBy(func(n *domain.Notification) bool {
+		return n.UserId == 3 && n.Type == domain.NotificationInvitationDeclined &&
+			n.Content == "Dev declined your invitation to devMatch"
+	})).Return(nil)
+	ctx := context.WithValue(context.Background(), "user_id", invitee)
+
+	err := m.useCase().RespondToInvitation(ctx, 11, false)
+
+	assert.NoError(t, err)
+	m.requests.AssertExpectations(t)
+	m.notifications.AssertExpectations(t)
+}
+
+func TestRespondToInvitation_NotInvitee(t *testing.T) {
+	invitee, other := 8, 4
+	m := newProjectActionMocks()
+	m.users.On("GetUserById", mock.Anything, other).Return(&domain.User{Id: other, Email: "other@example.com"}, nil)
+	m.requests.On("GetRequestById", mock.Anything, 11).Return(invitation(&invitee, nil), nil)
+	ctx := context.WithValue(context.Background(), "user_id", other)
+
+	err := m.useCase().RespondToInvitation(ctx, 11, true)
+
+	assert.ErrorIs(t, err, domain.ErrRequestNotFound)
+	m.requests.AssertNotCalled(t, "AcceptInvitation", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCancelInvitation(t *testing.T) {
+	invitee := 8
+	m := newProjectActionMocks()
+	m.requests.On("GetRequestById", mock.Anything, 11).Return(invitation(&invitee, nil), nil)
+	owner := 3
+	m.requests.On("TransitionRequest", mock.Anything, 11, domain.RequestCancelled, &owner, "").Return(nil)
+	m.notifications.On("Create", mock.Anything, mock.MatchedBy(func(n *domain.Notification) bool {
+		return n.UserId == invitee && n.Type == domain.NotificationInvitationCancelled &&
+			n.Content == "Your invitation to devMatch was withdrawn"
+	})).Return(nil)
+	ctx := context.WithValue(context.Background(), "user_id", owner)
+
+	err := m.useCase().CancelInvitation(ctx, 11)
+
+	assert.NoError(t, err)
+	m.requests.AssertExpectations(t)
+	m.notifications.AssertExpectations(t)
+}
+
+func TestCancelInvitation_NotOwner(t *testing.T) {
+	invitee := 8
+	m := newProjectActionMocks()
+	m.requests.On("GetRequestById", mock.Anything, 11).Return(invitation(&invitee, nil), nil)
+	tests := []struct {
+		name   string
+		userId int
+	}{
+		{"other user", 4},
+		{"invitee", invitee},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.WithValue(context.Background(), "user_id", tt.userId)
+
+			err := m.useCase().CancelInvitation(ctx, 11)
+
+			assert.ErrorIs(t, err, domain.ErrUserNotAllowed)
+			m.requests.AssertNotCalled(t, "TransitionRequest", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestCancelInvitation_Accepted(t *testing.T) {
+	invitee := 8
+	accepted := invitation(&invitee, nil)
+	accepted.Status = domain.RequestAccepted
+	m := newProjectActionMocks()
+	m.requests.On("GetRequestById", mock.Anything, 11).Return(accepted, nil)
+	ctx := context.WithValue(context.Background(), "user_id", 3)
+
+	err := m.useCase().CancelInvitation(ctx, 11)
+
+	assert.ErrorIs(t, err, domain.ErrInvalidTransition)
+	m.requests.AssertNotCalled(t, "TransitionRequest", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	m.notifications.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestRemoveMember(t *testing.T) {
+	member, owner := 8, 3
+	m := newProjectActionMocks()
+	m.requests.On("GetRequestById", mock.Anything, 1).Return(&domain.ProjectRequest{
+		Id: 1, ProjectId: 5, RoleId: 9, UserId: &member, Direction: domain.DirectionApplication, Status: domain.RequestAccepted,
+	}, nil)
+	m.requests.On("TransitionRequest", mock.Anything, 1, domain.RequestRemoved, &owner, "inactive").Return(nil)
+	m.requests.On("ReopenWaitlist", mock.Anything, 9, false).Return([]domain.ProjectRequest{}, nil)
+	m.notifications.On("Create", mock.Anything, mock.MatchedBy(func(n *domain.Notification) bool {
+		return n.UserId == member && n.Type == domain.NotificationMemberRemoved &&
+			n.Content == "You were removed from devMatch: inactive"
+	})).Return(nil)
+	ctx := context.WithValue(context.Background(), "user_id", owner)
+
+	err := m.useCase().RemoveMember(ctx, 1, &domain.RemoveMemberRequest{Reason: " inactive "})
+
+	assert.NoError(t, err)
+	m.requests.AssertExpectations(t)
+	m.notifications.AssertExpectations(t)
+}
+
+func TestRemoveMember_NotCreator(t *testing.T) {
+	member := 8
+	m := newProjectActionMocks()
+	m.requests.On("GetRequestById", mock.Anything, 1).Return(&domain.ProjectRequest{
+		Id: 1, ProjectId: 5, RoleId: 9, UserId: &member, Direction: domain.DirectionApplication, Status: domain.RequestAccepted,
+	}, nil)
+	tests := []struct {
+		name   string
+		userId int
+	}{
+		{"other user", 4},
+		// members cannot remove themselves, they withdraw
+		{"member", member},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.WithValue(context.Background(), "user_id", tt.userId)
+
+			err := m.useCase().RemoveMember(ctx, 1, &domain.RemoveMemberRequest{})
+
+			assert.ErrorIs(t, err, domain.ErrUserNotAllowed)
+			m.requests.AssertNotCalled(t, "TransitionRequest", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestRemoveMember_NotAccepted(t *testing.T) {
+	applicant := 8
+	m := newProjectActionMocks()
+	m.requests.On("GetRequestById", mock.Anything, 1).Return(&domain.ProjectRequest{
+		Id: 1, ProjectId: 5, RoleId: 9, UserId: &applicant, Direction: domain.DirectionApplication, Status: domain.RequestPending,
+	}, nil)
+	ctx := context.WithValue(context.Background(), "user_id", 3)
+
+	err := m.useCase().RemoveMember(ctx, 1, &domain.RemoveMemberRequest{})
+
+	assert.ErrorIs(t, err, domain.ErrInvalidTransition)
+	m.requests.AssertNotCalled(t, "TransitionRequest", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	m.notifications.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
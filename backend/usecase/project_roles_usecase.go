@@ -2,6 +2,8 @@ package usecase
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"time"
 
 	"github.com/iemran93/devMatch/domain"
@@ -26,6 +28,9 @@ func (pru *projectRolesUseCase) Create(c context.Context, req *domain.ProjectRol
 	ctx, cancel := context.WithTimeout(c, pru.contextTimeout)
 	defer cancel()
 
+	ctx, span := tracer.Start(ctx, "projectRolesUseCase.Create")
+	defer span.End()
+
 	// project exist ? userId is the creater ?
 	userId := ctx.Value("user_id")
 	project, err := pru.projectRepository.GetById(ctx, req.ProjectId)
@@ -36,24 +41,36 @@ func (pru *projectRolesUseCase) Create(c context.Context, req *domain.ProjectRol
 		return nil, domain.ErrProjectNotFound
 	}
 	if project.Creator.Id != userId {
-		return nil, domain.ErrUnauthorized
+		return nil, domain.ErrUserNotAllowed
 	}
 
-	return pru.projectRolesRepository.Create(ctx, req)
+	role, err := pru.projectRolesRepository.Create(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	repository.InvalidateProject(pru.projectRepository, req.ProjectId)
+	return role, nil
 }
 
 func (pru *projectRolesUseCase) Update(c context.Context, req *domain.ProjectRoleRequest, id int) (*domain.ProjectRole, error) {
 	ctx, cancel := context.WithTimeout(c, pru.contextTimeout)
 	defer cancel()
 
+	ctx, span := tracer.Start(ctx, "projectRolesUseCase.Update")
+	defer span.End()
+
 	userId := ctx.Value("user_id")
 	// role exist ? is woner?
-	_, err := pru.projectRolesRepository.Get(ctx, id)
+	role, err := pru.projectRolesRepository.Get(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrRoleNotFound
+	}
 	if err != nil {
 		return nil, err
 	}
 
-	project, err := pru.projectRepository.GetById(ctx, req.ProjectId)
+	// ownership is checked on the project the role belongs to, not the one in the body
+	project, err := pru.projectRepository.GetById(ctx, role.ProjectId)
 	if err != nil {
 		return nil, err
 	}
@@ -62,21 +79,32 @@ func (pru *projectRolesUseCase) Update(c context.Context, req *domain.ProjectRol
 		return nil, domain.ErrProjectNotFound
 	}
 	if project.Creator.Id != userId {
-		return nil, domain.ErrUnauthorized
+		return nil, domain.ErrUserNotAllowed
 	}
 
-	return pru.projectRolesRepository.Update(ctx, req, id)
+	updated, err := pru.projectRolesRepository.Update(ctx, req, id)
+	if err != nil {
+		return nil, err
+	}
+	repository.InvalidateProject(pru.projectRepository, role.ProjectId)
+	return updated, nil
 }
 
 func (pru *projectRolesUseCase) Delete(c context.Context, id int) error {
 	ctx, cancel := context.WithTimeout(c, pru.contextTimeout)
 	defer cancel()
 
+	ctx, span := tracer.Start(ctx, "projectRolesUseCase.Delete")
+	defer span.End()
+
 	userId := ctx.Value("user_id")
 
 	// role exist ? is owner ?
 
 	role, err := pru.projectRolesRepository.Get(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.ErrRoleNotFound
+	}
 	if err != nil {
 		return err
 	}
@@ -85,10 +113,128 @@ func (pru *projectRolesUseCase) Delete(c context.Context, id int) error {
 	if err != nil {
 		return err
 	}
+	if project == nil {
+		return domain.ErrProjectNotFound
+	}
 
 	if project.Creator.Id != userId {
-		return domain.ErrUnauthorized
+		return domain.ErrUserNotAllowed
 	}
 
-	return pru.projectRolesRepository.Delete(ctx, id)
+	if err := pru.projectRolesRepository.Delete(ctx, id); err != nil {
+		return err
+	}
+	repository.InvalidateProject(pru.projectRepository, role.ProjectId)
+	return nil
+}
+
+func (pru *projectRolesUseCase) ListQuestions(c context.Context, roleId int) ([]domain.RoleQuestion, error) {
+	ctx, cancel := context.WithTimeout(c, pru.contextTimeout)
+	defer cancel()
+
+	ctx, span := tracer.Start(ctx, "projectRolesUseCase.ListQuestions")
+	defer span.End()
+
+	_, err := pru.projectRolesRepository.Get(ctx, roleId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrRoleNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return pru.projectRolesRepository.ListQuestions(ctx, roleId)
+}
+
+func (pru *projectRolesUseCase) CreateQuestion(c context.Context, roleId int, req *domain.RoleQuestionRequest) (*domain.RoleQuestion, error) {
+	ctx, cancel := context.WithTimeout(c, pru.contextTimeout)
+	defer cancel()
+
+	ctx, span := tracer.Start(ctx, "projectRolesUseCase.CreateQuestion")
+	defer span.End()
+
+	if err := pru.checkOwner(ctx, roleId); err != nil {
+		return nil, err
+	}
+
+	id, err := pru.projectRolesRepository.CreateQuestion(ctx, roleId, req)
+	if err != nil {
+		return nil, err
+	}
+	return pru.projectRolesRepository.GetQuestion(ctx, id)
+}
+
+func (pru *projectRolesUseCase) UpdateQuestion(c context.Context, roleId int, questionId int, req *domain.RoleQuestionRequest) (*domain.RoleQuestion, error) {
+	ctx, cancel := context.WithTimeout(c, pru.contextTimeout)
+	defer cancel()
+
+	ctx, span := tracer.Start(ctx, "projectRolesUseCase.UpdateQuestion")
+	defer span.End()
+
+	if err := pru.checkQuestion(ctx, roleId, questionId); err != nil {
+		return nil, err
+	}
+
+	if err := pru.projectRolesRepository.UpdateQuestion(ctx, questionId, req); err != nil {
+		return nil, err
+	}
+	return pru.projectRolesRepository.GetQuestion(ctx, questionId)
+}
+
+func (pru *projectRolesUseCase) DeleteQuestion(c context.Context, roleId int, questionId int) error {
+	ctx, cancel := context.WithTimeout(c, pru.contextTimeout)
+	defer cancel()
+
+	ctx, span := tracer.Start(ctx, "projectRolesUseCase.DeleteQuestion")
+	defer span.End()
+
+	if err := pru.checkQuestion(ctx, roleId, questionId); err != nil {
+		return err
+	}
+
+	return pru.projectRolesRepository.DeleteQuestion(ctx, questionId)
+}
+
+// checkOwner verifies the role exists and its project belongs to the user
+// in ctx.
+func (pru *projectRolesUseCase) checkOwner(ctx context.Context, roleId int) error {
+	role, err := pru.projectRolesRepository.Get(ctx, roleId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.ErrRoleNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	project, err := pru.projectRepository.GetById(ctx, role.ProjectId)
+	if err != nil {
+		return err
+	}
+	if project == nil {
+		return domain.ErrProjectNotFound
+	}
+	userId, ok := ctx.Value("user_id").(int)
+	if !ok || project.Creator.Id != userId {
+		return domain.ErrUserNotAllowed
+	}
+	return nil
+}
+
+// checkQuestion is checkOwner plus the question belonging to the role.
+func (pru *projectRolesUseCase) checkQuestion(ctx context.Context, roleId int, questionId int) error {
+	if err := pru.checkOwner(ctx, roleId); err != nil {
+		return err
+	}
+
+	question, err := pru.projectRolesRepository.GetQuestion(ctx, questionId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.ErrQuestionNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if question.RoleId != roleId {
+		return domain.ErrQuestionNotFound
+	}
+	return nil
 }
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/iemran93/devMatch/domain"
+	"github.com/iemran93/devMatch/internal/metrics"
 	"github.com/iemran93/devMatch/repository"
 )
 
@@ -24,12 +25,16 @@ func (pu *projectUseCase) Create(c context.Context, req *domain.CreateProjectReq
 	ctx, cancel := context.WithTimeout(c, pu.contextTimeout)
 	defer cancel()
 
+	ctx, span := tracer.Start(ctx, "projectUseCase.Create")
+	defer span.End()
+
 	creatorId := ctx.Value("user_id").(int)
 
 	projectId, err := pu.projectRepository.Create(ctx, req, creatorId)
 	if err != nil {
 		return nil, err
 	}
+	metrics.ProjectsCreated.Inc()
 
 	project, err := pu.projectRepository.GetById(ctx, projectId)
 	if err != nil {
@@ -42,77 +47,133 @@ func (pu *projectUseCase) Create(c context.Context, req *domain.CreateProjectReq
 func (pu *projectUseCase) GetById(c context.Context, id int) (*domain.ProjectResponse, error) {
 	ctx, cancel := context.WithTimeout(c, pu.contextTimeout)
 	defer cancel()
+	ctx, span := tracer.Start(ctx, "projectUseCase.GetById")
+	defer span.End()
 	return pu.projectRepository.GetById(ctx, id)
 }
 
 func (pu *projectUseCase) List(c context.Context, filters map[string]any) ([]domain.ProjectResponse, error) {
 	ctx, cancel := context.WithTimeout(c, pu.contextTimeout)
 	defer cancel()
+	ctx, span := tracer.Start(ctx, "projectUseCase.List")
+	defer span.End()
 	return pu.projectRepository.List(ctx, filters)
 }
 
-func (pu *projectUseCase) Update(c context.Context, req *domain.UpdateProjectRequest, id int) error {
+func (pu *projectUseCase) Update(c context.Context, req *domain.UpdateProjectRequest, id int) (*domain.ProjectResponse, error) {
 	ctx, cancel := context.WithTimeout(c, pu.contextTimeout)
 	defer cancel()
 
-	// Get user ID from context and verify ownership
+	ctx, span := tracer.Start(ctx, "projectUseCase.Update")
+	defer span.End()
+
+	if err := pu.checkOwner(ctx, id); err != nil {
+		return nil, err
+	}
+
+	if err := pu.projectRepository.Update(ctx, req, id); err != nil {
+		return nil, err
+	}
+
+	// read it back so the client gets the new version
+	return pu.projectRepository.GetById(ctx, id)
+}
+
+func (pu *projectUseCase) Patch(c context.Context, req *domain.PatchProjectRequest, id int) (*domain.ProjectResponse, error) {
+	ctx, cancel := context.WithTimeout(c, pu.contextTimeout)
+	defer cancel()
+
+	ctx, span := tracer.Start(ctx, "projectUseCase.Patch")
+	defer span.End()
+
+	if err := pu.checkOwner(ctx, id); err != nil {
+		return nil, err
+	}
+
+	if err := pu.projectRepository.Patch(ctx, req, id); err != nil {
+		return nil, err
+	}
+	return pu.projectRepository.GetById(ctx, id)
+}
+
+// checkOwner verifies the project exists and belongs to the user in ctx.
+func (pu *projectUseCase) checkOwner(ctx context.Context, id int) error {
 	userId := ctx.Value("user_id").(int)
 	existingProject, err := pu.projectRepository.GetById(ctx, id)
 	if err != nil {
 		return err
 	}
+	if existingProject == nil {
+		return domain.ErrProjectNotFound
+	}
 	if existingProject.Creator.Id != userId {
-		return domain.ErrUnauthorized
+		return domain.ErrUserNotAllowed
 	}
-
-	return pu.projectRepository.Update(ctx, req, id)
+	return nil
 }
 
 func (pu *projectUseCase) Delete(c context.Context, id int) error {
 	ctx, cancel := context.WithTimeout(c, pu.contextTimeout)
 	defer cancel()
 
+	ctx, span := tracer.Start(ctx, "projectUseCase.Delete")
+	defer span.End()
+
 	// Get user ID from context and verify ownership
 	userId := ctx.Value("user_id").(int)
 	existingProject, err := pu.projectRepository.GetById(ctx, id)
-	if err != nil || existingProject == nil {
+	if err != nil {
+		return err
+	}
+	if existingProject == nil {
 		return domain.ErrProjectNotFound
 	}
 	if existingProject.Creator.Id != userId {
-		return domain.ErrUnauthorized
+		return domain.ErrUserNotAllowed
 	}
 
 	return pu.projectRepository.Delete(ctx, id)
 }
 
-func (pu *projectUseCase) GetCategory(c context.Context) ([]domain.Category, error) {
+func (pu *projectUseCase) GetCategory(c context.Context, q string) ([]domain.Category, error) {
 	ctx, cancel := context.WithTimeout(c, pu.contextTimeout)
 	defer cancel()
-	return pu.projectRepository.GetCategory(ctx)
+	ctx, span := tracer.Start(ctx, "projectUseCase.GetCategory")
+	defer span.End()
+	return pu.projectRepository.GetCategory(ctx, q)
 }
 
-func (pu *projectUseCase) GetTechnology(c context.Context) ([]domain.Technology, error) {
+func (pu *projectUseCase) GetTechnology(c context.Context, q string) ([]domain.Technology, error) {
 	ctx, cancel := context.WithTimeout(c, pu.contextTimeout)
 	defer cancel()
-	return pu.projectRepository.GetTechnology(ctx)
+	ctx, span := tracer.Start(ctx, "projectUseCase.GetTechnology")
+	defer span.End()
+	return pu.projectRepository.GetTechnology(ctx, q)
 }
 
-func (pu *projectUseCase) GetLanguage(c context.Context) ([]domain.Language, error) {
+func (pu *projectUseCase) GetLanguage(c context.Context, q string) ([]domain.Language, error) {
 	ctx, cancel := context.WithTimeout(c, pu.contextTimeout)
 	defer cancel()
-	return pu.projectRepository.GetLanguage(ctx)
+	ctx, span := tracer.Start(ctx, "projectUseCase.GetLanguage")
+	defer span.End()
+	return pu.projectRepository.GetLanguage(ctx, q)
 }
 
-func (pu *projectUseCase) GetType(c context.Context) ([]domain.Types, error) {
+func (pu *projectUseCase) GetType(c context.Context, q string) ([]domain.Types, error) {
 	ctx, cancel := context.WithTimeout(c, pu.contextTimeout)
 	defer cancel()
-	return pu.projectRepository.GetType(ctx)
+	ctx, span := tracer.Start(ctx, "projectUseCase.GetType")
+	defer span.End()
+	return pu.projectRepository.GetType(ctx, q)
 }
 
 func (pu *projectUseCase) GetByProjectId(c context.Context, id int) ([]*domain.ProjectRole, error) {
 	ctx, cancel := context.WithTimeout(c, pu.contextTimeout)
 	defer cancel()
 
+	ctx, span := tracer.Start(ctx, "projectUseCase.GetByProjectId")
+	defer span.End()
+
 	// project exist ?
 	project, err := pu.projectRepository.GetById(ctx, id)
 	if err != nil {
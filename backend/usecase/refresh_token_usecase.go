@@ -25,29 +25,32 @@ func NewRefreshTokenUseCase(userRepository repository.UserRepository, timeout ti
 }
 
 func (rtu *refreshTokenUseCase) RefreshToken(ctx context.Context, request domain.RefreshTokenRequest, env *bootstrap.Env) (accessToken string, refreshToken string, err error) {
+	ctx, span := tracer.Start(ctx, "refreshTokenUseCase.RefreshToken")
+	defer span.End()
+
 	var id int
 	id, err = tokenutil.ExtractIDFromToken(request.RefreshToken, env.RefreshTokenSecret)
 	if err != nil {
-		log.Error(err)
+		log.WithContext(ctx).Error(err)
 		return
 	}
 
 	var user *domain.User
 	user, err = rtu.userRepository.GetUserById(ctx, id)
 	if err != nil {
-		log.Error(err)
+		log.WithContext(ctx).Error(err)
 		return
 	}
 
 	accessToken, err = tokenutil.CreateAccessToken(user, env.AccessTokenSecret, env.AccessTokenExpiryHour)
 	if err != nil {
-		log.Error(err)
+		log.WithContext(ctx).Error(err)
 		return
 	}
 
 	refreshToken, err = tokenutil.CreateRefreshToken(user, env.RefreshTokenSecret, env.RefreshTokenExpiryHour)
 	if err != nil {
-		log.Error(err)
+		log.WithContext(ctx).Error(err)
 		return
 	}
 
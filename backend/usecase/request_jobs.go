@@ -0,0 +1,125 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/iemran93/devMatch/domain"
+	"github.com/iemran93/devMatch/internal/metrics"
+	"github.com/iemran93/devMatch/repository"
+	log "github.com/sirupsen/logrus"
+)
+
+// expireBatch caps the requests expired per run; the rest wait for the
+// next one.
+const expireBatch = 200
+
+// RequestJobsConfig holds the tunables of the request background jobs.
+// A zero duration disables what it applies to.
+type RequestJobsConfig struct {
+	// ExpireAfter is how long a pending application may wait for a reply.
+	ExpireAfter time.Duration
+	// RemindAfter is how long an application must be pending before its
+	// owner is reminded, and RemindEvery the least time between two
+	// reminders.
+	RemindAfter time.Duration
+	RemindEvery time.Duration
+}
+
+// RequestJobs are the scheduled jobs of the request workflow. Their
+// methods match scheduler.Job.Run.
+type RequestJobs struct {
+	projectActionsRepository repository.ProjectActionsRepo
+	notificationRepository   repository.NotificationRepository
+	config                   RequestJobsConfig
+}
+
+func NewRequestJobs(projectActionsRepository repository.ProjectActionsRepo, notificationRepository repository.NotificationRepository, config RequestJobsConfig) *RequestJobs {
+	return &RequestJobs{
+		projectActionsRepository: projectActionsRepository,
+		notificationRepository:   notificationRepository,
+		config:                   config,
+	}
+}
+
+// ExpireRequests expires applications pending longer than ExpireAfter and
+// invitations past their expiry, and tells the applicant or inviter.
+func (j *RequestJobs) ExpireRequests(ctx context.Context, now time.Time) error {
+	ctx, span := tracer.Start(ctx, "RequestJobs.ExpireRequests")
+	defer span.End()
+
+	var pendingBefore time.Time
+	if j.config.ExpireAfter > 0 {
+		pendingBefore = now.Add(-j.config.ExpireAfter)
+	}
+
+	requests, err := j.projectActionsRepository.ListExpirable(ctx, pendingBefore, now, expireBatch)
+	if err != nil {
+		return err
+	}
+
+	for _, request := range requests {
+		err := j.projectActionsRepository.TransitionRequest(ctx, request.Id, domain.RequestExpired, nil, "no reply in time")
+		if errors.Is(err, domain.ErrInvalidTransition) {
+			// answered since it was listed
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		metrics.ProjectRequests.WithLabelValues(domain.RequestExpired).Inc()
+
+		notification := &domain.Notification{
+			ProjectId: &request.ProjectId,
+			Type:      domain.NotificationRequestExpired,
+			CreatedAt: now,
+		}
+		switch {
+		case request.Direction == domain.DirectionInvitation && request.InvitedBy != nil:
+			notification.UserId = *request.InvitedBy
+			notification.Content = fmt.Sprintf("Your invitation for %s in %s expired without a reply", request.RoleTitle, request.ProjectTitle)
+		case request.Direction == domain.DirectionApplication && request.UserId != nil:
+			notification.UserId = *request.UserId
+			notification.Content = fmt.Sprintf("Your request for %s in %s expired without a reply", request.RoleTitle, request.ProjectTitle)
+		default:
+			continue
+		}
+		if err := j.notificationRepository.Create(ctx, notification); err != nil {
+			// the request is expired either way
+			log.WithContext(ctx).Error("Failed to notify about expired request: ", err)
+		}
+	}
+	return nil
+}
+
+// RemindOwners reminds owners of applications waiting longer than
+// RemindAfter, at most once every RemindEvery per project.
+func (j *RequestJobs) RemindOwners(ctx context.Context, now time.Time) error {
+	if j.config.RemindAfter <= 0 {
+		return nil
+	}
+
+	ctx, span := tracer.Start(ctx, "RequestJobs.RemindOwners")
+	defer span.End()
+
+	reminders, err := j.projectActionsRepository.ListAwaitingReview(ctx, now.Add(-j.config.RemindAfter), now.Add(-j.config.RemindEvery))
+	if err != nil {
+		return err
+	}
+
+	for _, reminder := range reminders {
+		err := j.notificationRepository.Create(ctx, &domain.Notification{
+			UserId:    reminder.OwnerId,
+			ProjectId: &reminder.ProjectId,
+			Type:      domain.NotificationRequestReminder,
+			Content:   fmt.Sprintf("%d requests are waiting for your review", reminder.Pending),
+			CreatedAt: now,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/iemran93/devMatch/domain"
+	"github.com/iemran93/devMatch/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockProjectActionsRepo mocks the methods the tests use; calling any other
+// method panics on the nil embedded interface.
+type MockProjectActionsRepo struct {
+	mock.Mock
+	repository.ProjectActionsRepo
+}
+
+func (m *MockProjectActionsRepo) TransitionRequest(ctx context.Context, requestId int, to string, actorId *int, note string) error {
+	return m.Called(ctx, requestId, to, actorId, note).Error(0)
+}
+
+func (m *MockProjectActionsRepo) ListExpirable(ctx context.Context, pendingBefore time.Time, now time.Time, limit int) ([]domain.ExpirableRequest, error) {
+	args := m.Called(ctx, pendingBefore, now, limit)
+	requests, _ := args.Get(0).([]domain.ExpirableRequest)
+	return requests, args.Error(1)
+}
+
+func (m *MockProjectActionsRepo) ListAwaitingReview(ctx context.Context, pendingBefore time.Time, remindedAfter time.Time) ([]domain.ReviewReminder, error) {
+	args := m.Called(ctx, pendingBefore, remindedAfter)
+	reminders, _ := args.Get(0).([]domain.ReviewReminder)
+	return reminders, args.Error(1)
+}
+
+type MockNotificationRepository struct {
+	mock.Mock
+}
+
+func (m *MockNotificationRepository) Create(ctx context.Context, n *domain.Notification) error {
+	return m.Called(ctx, n).Error(0)
+}
+
+func TestExpireRequests(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	applicant, owner := 7, 3
+
+	mockRepo := new(MockProjectActionsRepo)
+	mockRepo.On("ListExpirable", mock.Anything, now.Add(-30*24*time.Hour), now, expireBatch).Return([]domain.ExpirableRequest{
+		{ProjectRequest: domain.ProjectRequest{Id: 1, ProjectId: 5, RoleId: 9, UserId: &applicant, Direction: domain.DirectionApplication}, RoleTitle: "Backend", ProjectTitle: "devMatch"},
+		{ProjectRequest: domain.ProjectRequest{Id: 2, ProjectId: 5, RoleId: 9, InvitedBy: &owner, Direction: domain.DirectionInvitation}, RoleTitle: "Backend", ProjectTitle: "devMatch"},
+		{ProjectRequest: domain.ProjectRequest{Id: 3, ProjectId: 5, RoleId: 9, UserId: &applicant, Direction: domain.DirectionApplication}, RoleTitle: "Backend", ProjectTitle: "devMatch"},
+	}, nil)
+	mockRepo.On("TransitionRequest", mock.Anything, 1, domain.RequestExpired, (*int)(nil), mock.Anything).Return(nil)
+	mockRepo.On("TransitionRequest", mock.Anything, 2, domain.RequestExpired, (*int)(nil), mock.Anything).Return(nil)
+	// accepted by the owner after it was listed
+	mockRepo.On("TransitionRequest", mock.Anything, 3, domain.RequestExpired, (*int)(nil), mock.Anything).Return(domain.ErrInvalidTransition)
+
+	mockNotifications := new(MockNotificationRepository)
+	mockNotifications.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+	jobs := NewRequestJobs(mockRepo, mockNotifications, RequestJobsConfig{ExpireAfter: 30 * 24 * time.Hour})
+
+	assert.NoError(t, jobs.ExpireRequests(context.Background(), now))
+	mockRepo.AssertExpectations(t)
+
+	var notified []int
+	var contents []string
+	for _, call := range mockNotifications.Calls {
+		n := call.Arguments.Get(1).(*domain.Notification)
+		assert.Equal(t, domain.NotificationRequestExpired, n.Type)
+		assert.Equal(t, now, n.CreatedAt)
+		notified = append(notified, n.UserId)
+		contents = append(contents, n.Content)
+	}
+	assert.Equal(t, []int{applicant, owner}, notified)
+	assert.Equal(t, []string{
+		"Your request for Backend in devMatch expired without a reply",
+		"Your invitation for Backend in devMatch expired without a reply",
+	}, contents)
+}
+
+func TestRemindOwners(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	mockRepo := new(MockProjectActionsRepo)
+	mockRepo.On("ListAwaitingReview", mock.Anything, now.Add(-48*time.Hour), now.Add(-24*time.Hour)).Return([]domain.ReviewReminder{
+		{ProjectId: 5, OwnerId: 3, Pending: 2},
+	}, nil)
+
+	mockNotifications := new(MockNotificationRepository)
+	mockNotifications.On("Create", mock.Anything, mock.MatchedBy(func(n *domain.Notification) bool {
+		return n.UserId == 3 && *n.ProjectId == 5 && n.Type == domain.NotificationRequestReminder &&
+			n.Content == "2 requests are waiting for your review" && n.CreatedAt.Equal(now)
+	})).Return(nil)
+
+	jobs := NewRequestJobs(mockRepo, mockNotifications, RequestJobsConfig{
+		RemindAfter: 48 * time.Hour,
+		RemindEvery: 24 * time.Hour,
+	})
+
+	assert.NoError(t, jobs.RemindOwners(context.Background(), now))
+	mockRepo.AssertExpectations(t)
+	mockNotifications.AssertExpectations(t)
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/iemran93/devMatch/bootstrap"
 	"github.com/iemran93/devMatch/domain"
+	"github.com/iemran93/devMatch/internal/metrics"
 	"github.com/iemran93/devMatch/internal/tokenutil"
 	"github.com/iemran93/devMatch/repository"
 
@@ -26,10 +27,13 @@ func NewSignupUseCase(userRepository repository.UserRepository, timeout time.Dur
 }
 
 func (su *signupUseCase) SignUp(ctx context.Context, request domain.SignupRequest, env *bootstrap.Env) (accessToken string, refreshToken string, err error) {
+	ctx, span := tracer.Start(ctx, "signupUseCase.SignUp")
+	defer span.End()
+
 	// Check if user already exists
 	existingUser, _ := su.userRepository.GetUserByEmail(ctx, request.Email)
 	if existingUser != nil {
-		log.Error("User already exists")
+		log.WithContext(ctx).Error("User already exists")
 		err = domain.ErrUserAlreadyExists
 		return
 	}
@@ -39,7 +43,7 @@ func (su *signupUseCase) SignUp(ctx context.Context, request domain.SignupReques
 		bcrypt.DefaultCost,
 	)
 	if err != nil {
-		log.Error(err)
+		log.WithContext(ctx).Error(err)
 		return
 	}
 
@@ -55,19 +59,20 @@ func (su *signupUseCase) SignUp(ctx context.Context, request domain.SignupReques
 
 	user, err = su.userRepository.CreateUser(ctx, user)
 	if err != nil {
-		log.Error(err)
+		log.WithContext(ctx).Error(err)
 		return
 	}
+	metrics.Signups.WithLabelValues("password").Inc()
 
 	accessToken, err = tokenutil.CreateAccessToken(user, env.AccessTokenSecret, env.AccessTokenExpiryHour)
 	if err != nil {
-		log.Error(err)
+		log.WithContext(ctx).Error(err)
 		return
 	}
 
 	refreshToken, err = tokenutil.CreateRefreshToken(user, env.RefreshTokenSecret, env.RefreshTokenExpiryHour)
 	if err != nil {
-		log.Error(err)
+		log.WithContext(ctx).Error(err)
 		return
 	}
 
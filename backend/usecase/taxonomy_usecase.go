@@ -0,0 +1,272 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/iemran93/devMatch/domain"
+	"github.com/iemran93/devMatch/repository"
+)
+
+type taxonomyUseCase struct {
+	taxonomyRepository repository.TaxonomyRepository
+	projectRepository  repository.ProjectRepository
+	contextTimeout     time.Duration
+}
+
+func NewTaxonomyUseCase(tr repository.TaxonomyRepository, pr repository.ProjectRepository, timeout time.Duration) domain.TaxonomyUseCase {
+	return &taxonomyUseCase{
+		taxonomyRepository: tr,
+		projectRepository:  pr,
+		contextTimeout:     timeout,
+	}
+}
+
+func (tu *taxonomyUseCase) List(c context.Context, kind domain.TaxonomyKind) ([]domain.TaxonomyEntry, error) {
+	ctx, cancel := context.WithTimeout(c, tu.contextTimeout)
+	defer cancel()
+
+	ctx, span := tracer.Start(ctx, "taxonomyUseCase.List")
+	defer span.End()
+
+	if !kind.Valid() {
+		return nil, domain.ErrTaxonomyNotFound
+	}
+	return tu.taxonomyRepository.List(ctx, kind)
+}
+
+func (tu *taxonomyUseCase) Create(c context.Context, kind domain.TaxonomyKind, req *domain.CreateTaxonomyRequest) (*domain.TaxonomyEntry, error) {
+	ctx, cancel := context.WithTimeout(c, tu.contextTimeout)
+	defer cancel()
+
+	ctx, span := tracer.Start(ctx, "taxonomyUseCase.Create")
+	defer span.End()
+
+	if !kind.Valid() {
+		return nil, domain.ErrTaxonomyNotFound
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if err := tu.checkUnresolved(ctx, kind, name); err != nil {
+		return nil, err
+	}
+
+	id, err := tu.taxonomyRepository.Create(ctx, kind, name)
+	if err != nil {
+		return nil, err
+	}
+	repository.InvalidateTaxonomy(tu.projectRepository)
+	return tu.getById(ctx, kind, id)
+}
+
+func (tu *taxonomyUseCase) Update(c context.Context, kind domain.TaxonomyKind, id int, req *domain.UpdateTaxonomyRequest) (*domain.TaxonomyEntry, error) {
+	ctx, cancel := context.WithTimeout(c, tu.contextTimeout)
+	defer cancel()
+
+	ctx, span := tracer.Start(ctx, "taxonomyUseCase.Update")
+	defer span.End()
+
+	if !kind.Valid() {
+		return nil, domain.ErrTaxonomyNotFound
+	}
+	if _, err := tu.getById(ctx, kind, id); err != nil {
+		return nil, err
+	}
+
+	req.Name = strings.TrimSpace(req.Name)
+	if entry, err := tu.taxonomyRepository.Resolve(ctx, kind, req.Name); err == nil && entry.Id != id {
+		return nil, domain.ErrTaxonomyAlreadyExists.WithMessage(fmt.Sprintf("%q already refers to %q", req.Name, entry.Name))
+	} else if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	if err := tu.taxonomyRepository.Update(ctx, kind, id, req); err != nil {
+		return nil, err
+	}
+	repository.InvalidateTaxonomy(tu.projectRepository)
+	return tu.getById(ctx, kind, id)
+}
+
+func (tu *taxonomyUseCase) Merge(c context.Context, kind domain.TaxonomyKind, id int, req *domain.MergeTaxonomyRequest) error {
+	ctx, cancel := context.WithTimeout(c, tu.contextTimeout)
+	defer cancel()
+
+	ctx, span := tracer.Start(ctx, "taxonomyUseCase.Merge")
+	defer span.End()
+
+	if !kind.Valid() {
+		return domain.ErrTaxonomyNotFound
+	}
+	if id == req.TargetId {
+		return domain.ErrIncorrectRequestBody.WithMessage("cannot merge an entry into itself")
+	}
+
+	if _, err := tu.getById(ctx, kind, id); err != nil {
+		return err
+	}
+	if _, err := tu.getById(ctx, kind, req.TargetId); err != nil {
+		return err
+	}
+
+	if err := tu.taxonomyRepository.Merge(ctx, kind, id, req.TargetId); err != nil {
+		return err
+	}
+	repository.InvalidateTaxonomy(tu.projectRepository)
+	return nil
+}
+
+func (tu *taxonomyUseCase) ListAliases(c context.Context, kind domain.TaxonomyKind, id int) ([]domain.TaxonomyAlias, error) {
+	ctx, cancel := context.WithTimeout(c, tu.contextTimeout)
+	defer cancel()
+
+	ctx, span := tracer.Start(ctx, "taxonomyUseCase.ListAliases")
+	defer span.End()
+
+	if !kind.Valid() {
+		return nil, domain.ErrTaxonomyNotFound
+	}
+	if _, err := tu.getById(ctx, kind, id); err != nil {
+		return nil, err
+	}
+	return tu.taxonomyRepository.ListAliases(ctx, kind, id)
+}
+
+func (tu *taxonomyUseCase) CreateAlias(c context.Context, kind domain.TaxonomyKind, id int, req *domain.CreateTaxonomyAliasRequest) (*domain.TaxonomyAlias, error) {
+	ctx, cancel := context.WithTimeout(c, tu.contextTimeout)
+	defer cancel()
+
+	ctx, span := tracer.Start(ctx, "taxonomyUseCase.CreateAlias")
+	defer span.End()
+
+	if !kind.Valid() {
+		return nil, domain.ErrTaxonomyNotFound
+	}
+	if _, err := tu.getById(ctx, kind, id); err != nil {
+		return nil, err
+	}
+
+	alias := strings.TrimSpace(req.Alias)
+	if domain.NormalizeTaxonomyName(alias) == "" {
+		return nil, domain.ErrIncorrectRequestBody.WithMessage("alias must contain letters or digits")
+	}
+	if err := tu.checkUnresolved(ctx, kind, alias); err != nil {
+		return nil, err
+	}
+
+	aliasId, err := tu.taxonomyRepository.CreateAlias(ctx, kind, id, alias)
+	if err != nil {
+		return nil, err
+	}
+	return tu.taxonomyRepository.GetAlias(ctx, aliasId)
+}
+
+func (tu *taxonomyUseCase) DeleteAlias(c context.Context, kind domain.TaxonomyKind, id int, aliasId int) error {
+	ctx, cancel := context.WithTimeout(c, tu.contextTimeout)
+	defer cancel()
+
+	ctx, span := tracer.Start(ctx, "taxonomyUseCase.DeleteAlias")
+	defer span.End()
+
+	alias, err := tu.taxonomyRepository.GetAlias(ctx, aliasId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.ErrTaxonomyNotFound.WithMessage("alias not found")
+	}
+	if err != nil {
+		return err
+	}
+	if alias.Kind != kind || alias.EntryId != id {
+		return domain.ErrTaxonomyNotFound.WithMessage("alias not found")
+	}
+
+	return tu.taxonomyRepository.DeleteAlias(ctx, aliasId)
+}
+
+func (tu *taxonomyUseCase) ProposeTechnology(c context.Context, req *domain.ProposeTechnologyRequest) (*domain.TechnologyProposal, error) {
+	ctx, cancel := context.WithTimeout(c, tu.contextTimeout)
+	defer cancel()
+
+	ctx, span := tracer.Start(ctx, "taxonomyUseCase.ProposeTechnology")
+	defer span.End()
+
+	userId := ctx.Value("user_id").(int)
+	name := strings.TrimSpace(req.Name)
+
+	if err := tu.checkUnresolved(ctx, domain.TaxonomyTechnology, name); err != nil {
+		return nil, err
+	}
+
+	_, err := tu.taxonomyRepository.GetPendingProposalByName(ctx, name)
+	if err == nil {
+		return nil, domain.ErrTaxonomyAlreadyExists.WithMessage("technology already proposed")
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	id, err := tu.taxonomyRepository.CreateProposal(ctx, name, userId)
+	if errors.Is(err, domain.ErrTaxonomyAlreadyExists) {
+		// proposed concurrently
+		return nil, domain.ErrTaxonomyAlreadyExists.WithMessage("technology already proposed")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return tu.taxonomyRepository.GetProposal(ctx, id)
+}
+
+func (tu *taxonomyUseCase) ListProposals(c context.Context, status string) ([]domain.TechnologyProposal, error) {
+	ctx, cancel := context.WithTimeout(c, tu.contextTimeout)
+	defer cancel()
+
+	ctx, span := tracer.Start(ctx, "taxonomyUseCase.ListProposals")
+	defer span.End()
+
+	return tu.taxonomyRepository.ListProposals(ctx, status)
+}
+
+func (tu *taxonomyUseCase) ReviewProposal(c context.Context, id int, approve bool) (*domain.TechnologyProposal, error) {
+	ctx, cancel := context.WithTimeout(c, tu.contextTimeout)
+	defer cancel()
+
+	ctx, span := tracer.Start(ctx, "taxonomyUseCase.ReviewProposal")
+	defer span.End()
+
+	reviewerId := ctx.Value("user_id").(int)
+
+	err := tu.taxonomyRepository.ReviewProposal(ctx, id, reviewerId, approve)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrProposalNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if approve {
+		repository.InvalidateTaxonomy(tu.projectRepository)
+	}
+	return tu.taxonomyRepository.GetProposal(ctx, id)
+}
+
+// checkUnresolved fails when name already refers to an entry of kind, by
+// name or alias.
+func (tu *taxonomyUseCase) checkUnresolved(ctx context.Context, kind domain.TaxonomyKind, name string) error {
+	entry, err := tu.taxonomyRepository.Resolve(ctx, kind, name)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return domain.ErrTaxonomyAlreadyExists.WithMessage(fmt.Sprintf("%q already refers to %q", name, entry.Name))
+}
+
+func (tu *taxonomyUseCase) getById(ctx context.Context, kind domain.TaxonomyKind, id int) (*domain.TaxonomyEntry, error) {
+	entry, err := tu.taxonomyRepository.GetById(ctx, kind, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrTaxonomyNotFound
+	}
+	return entry, err
+}
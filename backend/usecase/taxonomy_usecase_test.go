@@ -0,0 +1,154 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/iemran93/devMatch/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockTaxonomyRepository struct {
+	mock.Mock
+}
+
+func (m *MockTaxonomyRepository) List(ctx context.Context, kind domain.TaxonomyKind) ([]domain.TaxonomyEntry, error) {
+	args := m.Called(ctx, kind)
+	entries, _ := args.Get(0).([]domain.TaxonomyEntry)
+	return entries, args.Error(1)
+}
+
+func (m *MockTaxonomyRepository) GetById(ctx context.Context, kind domain.TaxonomyKind, id int) (*domain.TaxonomyEntry, error) {
+	args := m.Called(ctx, kind, id)
+	entry, _ := args.Get(0).(*domain.TaxonomyEntry)
+	return entry, args.Error(1)
+}
+
+func (m *MockTaxonomyRepository) Resolve(ctx context.Context, kind domain.TaxonomyKind, name string) (*domain.TaxonomyEntry, error) {
+	args := m.Called(ctx, kind, name)
+	entry, _ := args.Get(0).(*domain.TaxonomyEntry)
+	return entry, args.Error(1)
+}
+
+func (m *MockTaxonomyRepository) Create(ctx context.Context, kind domain.TaxonomyKind, name string) (int, error) {
+	args := m.Called(ctx, kind, name)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockTaxonomyRepository) Update(ctx context.Context, kind domain.TaxonomyKind, id int, req *domain.UpdateTaxonomyRequest) error {
+	return m.Called(ctx, kind, id, req).Error(0)
+}
+
+func (m *MockTaxonomyRepository) Merge(ctx context.Context, kind domain.TaxonomyKind, sourceId, targetId int) error {
+	return m.Called(ctx, kind, sourceId, targetId).Error(0)
+}
+
+func (m *MockTaxonomyRepository) ListAliases(ctx context.Context, kind domain.TaxonomyKind, entryId int) ([]domain.TaxonomyAlias, error) {
+	args := m.Called(ctx, kind, entryId)
+	aliases, _ := args.Get(0).([]domain.TaxonomyAlias)
+	return aliases, args.Error(1)
+}
+
+func (m *MockTaxonomyRepository) GetAlias(ctx context.Context, id int) (*domain.TaxonomyAlias, error) {
+	args := m.Called(ctx, id)
+	alias, _ := args.Get(0).(*domain.TaxonomyAlias)
+	return alias, args.Error(1)
+}
+
+func (m *MockTaxonomyRepository) CreateAlias(ctx context.Context, kind domain.TaxonomyKind, entryId int, alias string) (int, error) {
+	args := m.Called(ctx, kind, entryId, alias)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockTaxonomyRepository) DeleteAlias(ctx context.Context, id int) error {
+	return m.Called(ctx, id).Error(0)
+}
+
+func (m *MockTaxonomyRepository) CreateProposal(ctx context.Context, name string, userId int) (int, error) {
+	args := m.Called(ctx, name, userId)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockTaxonomyRepository) GetProposal(ctx context.Context, id int) (*domain.TechnologyProposal, error) {
+	args := m.Called(ctx, id)
+	proposal, _ := args.Get(0).(*domain.TechnologyProposal)
+	return proposal, args.Error(1)
+}
+
+func (m *MockTaxonomyRepository) GetPendingProposalByName(ctx context.Context, name string) (*domain.TechnologyProposal, error) {
+	args := m.Called(ctx, name)
+	proposal, _ := args.Get(0).(*domain.TechnologyProposal)
+	return proposal, args.Error(1)
+}
+
+func (m *MockTaxonomyRepository) ListProposals(ctx context.Context, status string) ([]domain.TechnologyProposal, error) {
+	args := m.Called(ctx, status)
+	proposals, _ := args.Get(0).([]domain.TechnologyProposal)
+	return proposals, args.Error(1)
+}
+
+func (m *MockTaxonomyRepository) ReviewProposal(ctx context.Context, id int, reviewerId int, approve bool) error {
+	return m.Called(ctx, id, reviewerId, approve).Error(0)
+}
+
+func TestTaxonomyMerge_Success(t *testing.T) {
+	mockRepo := new(MockTaxonomyRepository)
+	mockRepo.On("GetById", mock.Anything, domain.TaxonomyTechnology, 1).Return(&domain.TaxonomyEntry{Id: 1, Name: "golang"}, nil)
+	mockRepo.On("GetById", mock.Anything, domain.TaxonomyTechnology, 2).Return(&domain.TaxonomyEntry{Id: 2, Name: "Go"}, nil)
+	mockRepo.On("Merge", mock.Anything, domain.TaxonomyTechnology, 1, 2).Return(nil)
+
+	tu := NewTaxonomyUseCase(mockRepo, nil, time.Second*5)
+
+	err := tu.Merge(context.Background(), domain.TaxonomyTechnology, 1, &domain.MergeTaxonomyRequest{TargetId: 2})
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestTaxonomyMerge_UnknownTarget(t *testing.T) {
+	mockRepo := new(MockTaxonomyRepository)
+	mockRepo.On("GetById", mock.Anything, domain.TaxonomyLanguage, 1).Return(&domain.TaxonomyEntry{Id: 1, Name: "Go"}, nil)
+	mockRepo.On("GetById", mock.Anything, domain.TaxonomyLanguage, 9).Return(nil, sql.ErrNoRows)
+
+	tu := NewTaxonomyUseCase(mockRepo, nil, time.Second*5)
+
+	err := tu.Merge(context.Background(), domain.TaxonomyLanguage, 1, &domain.MergeTaxonomyRequest{TargetId: 9})
+
+	assert.ErrorIs(t, err, domain.ErrTaxonomyNotFound)
+	mockRepo.AssertNotCalled(t, "Merge", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestProposeTechnology_AlreadyExists(t *testing.T) {
+	mockRepo := new(MockTaxonomyRepository)
+	mockRepo.On("Resolve", mock.Anything, domain.TaxonomyTechnology, "Go").Return(&domain.TaxonomyEntry{Id: 2, Name: "Go"}, nil)
+
+	tu := NewTaxonomyUseCase(mockRepo, nil, time.Second*5)
+	ctx := context.WithValue(context.Background(), "user_id", 1)
+
+	proposal, err := tu.ProposeTechnology(ctx, &domain.ProposeTechnologyRequest{Name: " Go "})
+
+	assert.ErrorIs(t, err, domain.ErrTaxonomyAlreadyExists)
+	assert.Nil(t, proposal)
+	mockRepo.AssertNotCalled(t, "CreateProposal", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestProposeTechnology_ProposedConcurrently(t *testing.T) {
+	mockRepo := new(MockTaxonomyRepository)
+	mockRepo.On("Resolve", mock.Anything, domain.TaxonomyTechnology, "Bun").Return(nil, sql.ErrNoRows)
+	mockRepo.On("GetPendingProposalByName", mock.Anything, "Bun").Return(nil, sql.ErrNoRows)
+	// the unique pending name rejects the second insert
+	mockRepo.On("CreateProposal", mock.Anything, "Bun", 1).Return(0, domain.ErrTaxonomyAlreadyExists)
+
+	tu := NewTaxonomyUseCase(mockRepo, nil, time.Second*5)
+	ctx := context.WithValue(context.Background(), "user_id", 1)
+
+	proposal, err := tu.ProposeTechnology(ctx, &domain.ProposeTechnologyRequest{Name: "Bun"})
+
+	assert.ErrorIs(t, err, domain.ErrTaxonomyAlreadyExists)
+	assert.Nil(t, proposal)
+	mockRepo.AssertNotCalled(t, "GetProposal", mock.Anything, mock.Anything)
+}
@@ -2,6 +2,8 @@ package usecase
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"time"
 
 	"github.com/iemran93/devMatch/domain"
@@ -23,6 +25,8 @@ func NewUserUseCase(userRepository repository.UserRepository, timeout time.Durat
 func (uu *userUseCase) GetUsers(c context.Context) ([]*domain.UserResponse, error) {
 	ctx, cancel := context.WithTimeout(c, uu.contextTimeout)
 	defer cancel()
+	ctx, span := tracer.Start(ctx, "userUseCase.GetUsers")
+	defer span.End()
 	var urs []*domain.UserResponse
 	users, err := uu.userRepository.GetUsers(ctx)
 	if err != nil {
@@ -37,6 +41,7 @@ func (uu *userUseCase) GetUsers(c context.Context) ([]*domain.UserResponse, erro
 			Email:          user.Email,
 			Availability:   user.Availability,
 			CreatedAt:      user.CreatedAt,
+			IsAdmin:        user.IsAdmin,
 		})
 	}
 	return urs, nil
@@ -45,8 +50,13 @@ func (uu *userUseCase) GetUsers(c context.Context) ([]*domain.UserResponse, erro
 func (uu *userUseCase) GetUserById(c context.Context, id int) (*domain.UserResponse, error) {
 	ctx, cancel := context.WithTimeout(c, uu.contextTimeout)
 	defer cancel()
+	ctx, span := tracer.Start(ctx, "userUseCase.GetUserById")
+	defer span.End()
 	var ur *domain.UserResponse
 	user, err := uu.userRepository.GetUserById(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrUserNotFound
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -58,6 +68,7 @@ func (uu *userUseCase) GetUserById(c context.Context, id int) (*domain.UserRespo
 		Email:          user.Email,
 		Availability:   user.Availability,
 		CreatedAt:      user.CreatedAt,
+		IsAdmin:        user.IsAdmin,
 	}
 	return ur, nil
 }
@@ -65,11 +76,15 @@ func (uu *userUseCase) GetUserById(c context.Context, id int) (*domain.UserRespo
 func (uu *userUseCase) UpdateUser(c context.Context, user *domain.User) error {
 	ctx, cancel := context.WithTimeout(c, uu.contextTimeout)
 	defer cancel()
+	ctx, span := tracer.Start(ctx, "userUseCase.UpdateUser")
+	defer span.End()
 	return uu.userRepository.UpdateUser(ctx, user)
 }
 
 func (uu *userUseCase) DeleteUser(c context.Context, id int) error {
 	ctx, cancel := context.WithTimeout(c, uu.contextTimeout)
 	defer cancel()
+	ctx, span := tracer.Start(ctx, "userUseCase.DeleteUser")
+	defer span.End()
 	return uu.userRepository.DeleteUser(ctx, id)
 }
@@ -143,6 +143,22 @@ func TestGetUserById_Error(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 }
 
+func TestGetUserById_NotFound(t *testing.T) {
+	mockRepo := new(MockUserRepository)
+
+	// The repository reports a missing row, the usecase maps it to a domain error
+	mockRepo.On("GetUserById", mock.Anything, 3).Return(nil, sql.ErrNoRows)
+
+	uu := NewUserUseCase(mockRepo, time.Second*5)
+
+	userResponse, err := uu.GetUserById(context.Background(), 3)
+
+	assert.ErrorIs(t, err, domain.ErrUserNotFound)
+	assert.Nil(t, userResponse)
+
+	mockRepo.AssertExpectations(t)
+}
+
 func TestGetUsers_Success(t *testing.T) {
 	// Create a mock repository
 	mockRepo := new(MockUserRepository)
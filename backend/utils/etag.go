@@ -0,0 +1,87 @@
+package utils
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// Cache-Control values for cacheable GET responses. Project data changes
+// with every edit, so clients revalidate it each time; the ETag makes that a
+// cheap 304. Taxonomy changes rarely and may be reused for a few minutes.
+const (
+	CacheRevalidate = "public, no-cache"
+	CacheTaxonomy   = "public, max-age=300"
+)
+
+// ETag returns a strong entity tag for obj: a hash of its JSON encoding,
+// which is exactly the body JSON writes.
+func ETag(obj any) (string, error) {
+	body, err := encodeJSON(obj)
+	if err != nil {
+		return "", err
+	}
+	return etagFor(body), nil
+}
+
+// CachedJSON writes obj like JSON with an ETag and Cache-Control header. If
+// the request's If-None-Match matches it answers 304 Not Modified without a
+// body.
+func CachedJSON(w http.ResponseWriter, r *http.Request, cacheControl string, obj any) {
+	body, err := encodeJSON(obj)
+	if err != nil {
+		Error(w, r, err)
+		return
+	}
+
+	etag := etagFor(body)
+	h := w.Header()
+	h.Set("ETag", etag)
+	h.Set("Cache-Control", cacheControl)
+
+	if MatchETag(r.Header.Get("If-None-Match"), etag, true) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	h.Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(body)
+}
+
+// MatchETag reports whether a If-Match or If-None-Match header value lists
+// etag. "*" matches any current representation. If-None-Match uses weak
+// comparison, If-Match strong comparison (RFC 9110 13.1).
+func MatchETag(header, etag string, weak bool) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" {
+			return true
+		}
+		if weak {
+			candidate = strings.TrimPrefix(candidate, "W/")
+		} else if strings.HasPrefix(candidate, "W/") {
+			continue
+		}
+		if candidate != "" && candidate == etag {
+			return true
+		}
+	}
+	return false
+}
+
+func encodeJSON(obj any) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(obj); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func etagFor(body []byte) string {
+	sum := sha256.Sum256(body)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
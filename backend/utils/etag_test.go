@@ -0,0 +1,39 @@
+package utils
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCachedJSON_NotModified(t *testing.T) {
+	body := map[string]int{"id": 1}
+
+	rec := httptest.NewRecorder()
+	CachedJSON(rec, httptest.NewRequest(http.MethodGet, "/api/projects/1", nil), CacheRevalidate, body)
+
+	etag := rec.Header().Get("ETag")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.NotEmpty(t, etag)
+	assert.Equal(t, CacheRevalidate, rec.Header().Get("Cache-Control"))
+	assert.JSONEq(t, `{"id":1}`, rec.Body.String())
+
+	req := httptest.NewRequest(http.MethodGet, "/api/projects/1", nil)
+	req.Header.Set("If-None-Match", `"other", W/`+etag)
+	rec = httptest.NewRecorder()
+	CachedJSON(rec, req, CacheRevalidate, body)
+
+	assert.Equal(t, http.StatusNotModified, rec.Code)
+	assert.Empty(t, rec.Body.String())
+	assert.Equal(t, etag, rec.Header().Get("ETag"))
+}
+
+func TestMatchETag(t *testing.T) {
+	assert.True(t, MatchETag(`"a", "b"`, `"b"`, false))
+	assert.True(t, MatchETag(`*`, `"b"`, false))
+	assert.False(t, MatchETag(`W/"b"`, `"b"`, false))
+	assert.True(t, MatchETag(`W/"b"`, `"b"`, true))
+	assert.False(t, MatchETag(``, `"b"`, true))
+}
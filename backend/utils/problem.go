@@ -0,0 +1,50 @@
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/iemran93/devMatch/domain"
+	log "github.com/sirupsen/logrus"
+)
+
+const problemContentType = "application/problem+json"
+
+// Error writes err as an RFC 7807 problem+json response. Domain errors carry
+// their own status and message; any other error is logged and reported as an
+// internal server error so driver or SQL details never reach the client.
+func Error(w http.ResponseWriter, r *http.Request, err error) {
+	var de *domain.Error
+	if !errors.As(err, &de) {
+		log.WithContext(r.Context()).Error(err)
+		de = domain.ErrInternalServerError
+	} else if de.Status >= http.StatusInternalServerError {
+		log.WithContext(r.Context()).Error(err)
+	}
+
+	problem := domain.ProblemDetails{
+		Type:     "about:blank",
+		Title:    http.StatusText(de.Status),
+		Status:   de.Status,
+		Detail:   de.Message,
+		Instance: r.URL.Path,
+		Code:     de.Code,
+	}
+	problem.RequestId, _ = r.Context().Value("request_id").(string)
+
+	var ve *domain.ValidationError
+	if errors.As(err, &ve) {
+		problem.Errors = ve.Errors
+	}
+
+	Problem(w, de.Status, problem)
+}
+
+// Problem writes any problem body, including ones extended with extra members.
+func Problem(w http.ResponseWriter, code int, problem any) {
+	w.Header().Set("Content-Type", problemContentType)
+	w.WriteHeader(code)
+	enc := json.NewEncoder(w)
+	enc.Encode(problem)
+}
@@ -1,16 +1,20 @@
 package utils
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"net/http"
+	"os"
+	"strings"
 
 	"github.com/iemran93/devMatch/bootstrap"
 	"github.com/jmoiron/sqlx"
 
 	_ "github.com/go-sql-driver/mysql"
 	"github.com/golang-migrate/migrate/v4"
-	_ "github.com/golang-migrate/migrate/v4/database/mysql"
+	"github.com/golang-migrate/migrate/v4/database/mysql"
+	"github.com/golang-migrate/migrate/v4/source"
 	_ "github.com/golang-migrate/migrate/v4/source/file"
 
 	log "github.com/sirupsen/logrus"
@@ -37,6 +41,67 @@ func MigrateDB(db *sqlx.DB, env *bootstrap.Env) {
 	}
 }
 
+// GrantAdmins sets is_admin on the accounts listed in ADMIN_EMAILS, the
+// only way to get a first admin without editing the database.
+func GrantAdmins(db *sqlx.DB, env *bootstrap.Env) {
+	var emails []string
+	for _, email := range strings.Split(env.AdminEmails, ",") {
+		if email = strings.ToLower(strings.TrimSpace(email)); email != "" {
+			emails = append(emails, email)
+		}
+	}
+	if len(emails) == 0 {
+		return
+	}
+
+	query, args, err := sqlx.In("UPDATE User SET is_admin = true WHERE LOWER(email) IN (?)", emails)
+	if err != nil {
+		log.Error("error while granting admins: ", err)
+		return
+	}
+	result, err := db.Exec(query, args...)
+	if err != nil {
+		log.Error("error while granting admins: ", err)
+		return
+	}
+	if granted, err := result.RowsAffected(); err == nil && granted > 0 {
+		log.Info("granted admin to ", granted, " accounts from ADMIN_EMAILS")
+	}
+}
+
+// LatestMigrationVersion returns the highest migration version found in the
+// migrations directory, i.e. the version a fully migrated database reports.
+func LatestMigrationVersion(env *bootstrap.Env) (uint, error) {
+	src, err := source.Open("file://" + env.MigrationPath)
+	if err != nil {
+		return 0, err
+	}
+	defer src.Close()
+
+	version, err := src.First()
+	if err != nil {
+		return 0, err
+	}
+	for {
+		next, err := src.Next(version)
+		if errors.Is(err, os.ErrNotExist) {
+			return version, nil
+		}
+		if err != nil {
+			return 0, err
+		}
+		version = next
+	}
+}
+
+// CurrentMigrationVersion reads the version golang-migrate recorded in the
+// database.
+func CurrentMigrationVersion(ctx context.Context, db *sqlx.DB) (version uint, dirty bool, err error) {
+	query := "SELECT version, dirty FROM `" + mysql.DefaultMigrationsTable + "` LIMIT 1"
+	err = db.QueryRowContext(ctx, query).Scan(&version, &dirty)
+	return
+}
+
 func SetCookie(w http.ResponseWriter, name string, value string) {
 	// For development environment (http://localhost)
 	// In production, you'd set Secure: true
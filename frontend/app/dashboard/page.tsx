@@ -4,9 +4,11 @@ import { useAuth } from "@/context/auth-context";
 import { ProtectedRoute } from "@/components/auth/protected-route";
 import { Button } from "@/components/ui/button";
 import { Card, CardContent, CardDescription, CardFooter, CardHeader, CardTitle } from "@/components/ui/card";
+import { useGetApplicationUsage } from "@/lib/requests/project_action_request";
 
 export default function DashboardPage() {
   const { user, logout } = useAuth();
+  const { data: usage } = useGetApplicationUsage();
 
   return (
     <ProtectedRoute>
@@ -53,6 +55,34 @@ export default function DashboardPage() {
               </p>
             </CardContent>
           </Card>
+
+          <Card>
+            <CardHeader>
+              <CardTitle>Applications</CardTitle>
+              <CardDescription>Your open applications and limits</CardDescription>
+            </CardHeader>
+            <CardContent className="space-y-4">
+              <div>
+                <h3 className="text-sm font-medium text-muted-foreground">Open applications</h3>
+                <p className="text-lg">
+                  {usage?.open_applications ?? 0}
+                  {usage?.max_open_applications ? ` of ${usage.max_open_applications}` : ""}
+                </p>
+              </div>
+              {usage?.cooldowns && usage.cooldowns.length > 0 && (
+                <div>
+                  <h3 className="text-sm font-medium text-muted-foreground">Cooling down</h3>
+                  <ul className="text-sm space-y-1">
+                    {usage.cooldowns.map((c) => (
+                      <li key={c.role_id}>
+                        Role {c.role_id}: apply again after {new Date(c.until).toLocaleString()}
+                      </li>
+                    ))}
+                  </ul>
+                </div>
+              )}
+            </CardContent>
+          </Card>
         </div>
       </div>
     </ProtectedRoute>
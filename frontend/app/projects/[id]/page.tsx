@@ -71,8 +71,8 @@ export default function ProjectPage() {
     title: '',
     project_id: 0,
     description: '',
-    required_experience_level: 1,
-    is_filled: false,
+    experience_level: 'mid',
+    seats: 1,
   })
 
   // query
@@ -128,8 +128,8 @@ export default function ProjectPage() {
       setNewRole({
         title: '',
         description: '',
-        required_experience_level: 1,
-        is_filled: false,
+        experience_level: 'mid',
+        seats: 1,
       })
     } catch (error) {
       toast({
@@ -162,10 +162,9 @@ export default function ProjectPage() {
         title: editingRole.title,
         project_id: parseInt(id),
         description: editingRole.description,
-        required_experience_level: parseInt(
-          editingRole.required_experience_level,
-        ),
-        is_filled: editingRole.is_filled,
+        experience_level: editingRole.experience_level,
+        seats: editingRole.seats,
+        version: editingRole.version,
       }
 
       await updateProjectRole.mutateAsync({
@@ -238,6 +237,7 @@ export default function ProjectPage() {
                   onClick={() => {
                     setEditingProject({
                       id: project.id,
+                      version: project.version,
                       title: project.title,
                       description: project.description,
                       goals: project.goals || '',
@@ -356,17 +356,16 @@ export default function ProjectPage() {
                           <div className="flex items-center gap-1">
                             <Star className="h-4 w-4" />
                             <span>
-                              Level {role.required_experience_level} -{' '}
-                              {getExperienceLevelText(
-                                parseInt(role.required_experience_level),
-                              )}
+                              {getExperienceLevelText(role.experience_level)}
                             </span>
                           </div>
                           <Badge
                             variant={role.is_filled ? 'default' : 'secondary'}
                             className="text-xs"
                           >
-                            {role.is_filled ? 'Filled' : 'Open'}
+                            {role.is_filled
+                              ? 'Filled'
+                              : `${role.seats_remaining} of ${role.seats} open`}
                           </Badge>
                         </div>
                       </div>
@@ -389,6 +388,23 @@ export default function ProjectPage() {
                         {role.description}
                       </p>
                     )}
+                    {(role.technologies?.length > 0 ||
+                      role.languages?.length > 0) && (
+                      <div className="flex flex-wrap gap-2">
+                        {[...role.technologies, ...role.languages].map(
+                          (skill) => (
+                            <Badge
+                              key={`${skill.name}-${skill.id}`}
+                              variant={skill.required ? 'default' : 'outline'}
+                              className="text-xs"
+                            >
+                              {skill.name}
+                              {!skill.required && ' (nice to have)'}
+                            </Badge>
+                          ),
+                        )}
+                      </div>
+                    )}
                     {isAuthenticated &&
                       !isCreator &&
                       (() => {
@@ -408,6 +424,13 @@ export default function ProjectPage() {
                             pr.status === 'pending',
                         )
 
+                        const isWaitlisted = projectRequests?.some(
+                          (pr) =>
+                            pr.role_id === role.id &&
+                            pr.user_id === Number(user?.id) &&
+                            pr.status === 'waitlisted',
+                        )
+
                         const hasRegected = projectRequests?.some(
                           (pr) =>
                             pr.role_id === role.id &&
@@ -429,15 +452,31 @@ export default function ProjectPage() {
                             handleClick = () => {}
                             buttonVariant = 'success'
                             isDisabled = true
-                          } else {
+                          } else if (isWaitlisted) {
+                            buttonText = 'Leave Waitlist'
+                            handleClick = () =>
+                              cancelRoleMutation.mutate({
+                                project_id: role.project_id,
+                                role_id: role.id,
+                              })
+                            buttonVariant = 'outline'
+                          } else if (hasRegected) {
                             showButton = false
-                            buttonText = 'Filled'
-                            handleClick = () => {}
-                            isDisabled = true
+                          } else {
+                            // applying to a filled role joins its waitlist
+                            buttonText = 'Join Waitlist'
+                            handleClick = () =>
+                              applyRoleMutation.mutate({
+                                project_id: role.project_id,
+                                role_id: role.id,
+                              })
+                            buttonVariant = 'outline'
                           }
                         } else {
-                          if (hasPendingRequest) {
-                            buttonText = 'Cancel Application'
+                          if (hasPendingRequest || isWaitlisted) {
+                            buttonText = isWaitlisted
+                              ? 'Leave Waitlist'
+                              : 'Cancel Application'
                             handleClick = () =>
                               cancelRoleMutation.mutate({
                                 project_id: role.project_id,
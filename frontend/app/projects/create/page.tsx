@@ -31,12 +31,14 @@ import {
   createProjectSchema,
   CreateProjectFormData,
   CreateProjectRequest,
+  experienceLevels,
 } from "@/lib/types/project_types"
 import {
   useCreateProject,
   useGetAllProjectData,
 } from "@/lib/requests/project_requests"
 import { Loading } from "@/components/layout/loading"
+import { getExperienceLevelText } from "@/components/layout/components_helper"
 
 const projectStages = ["Idea", "In Progress", "Completed"] as const
 
@@ -74,7 +76,7 @@ export default function CreateProjectPage() {
       technologies: [],
       languages: [],
       project_roles: [
-        { title: "", description: "", required_experience_level: 1 },
+        { title: "", description: "", experience_level: "mid" },
       ],
     },
   })
@@ -99,7 +101,7 @@ export default function CreateProjectPage() {
         project_roles: data.project_roles.map((role) => ({
           title: role.title,
           description: role.description || "",
-          required_experience_level: role.required_experience_level,
+          experience_level: role.experience_level,
         })),
       }
 
@@ -322,7 +324,7 @@ export default function CreateProjectPage() {
                       append({
                         title: "",
                         description: "",
-                        required_experience_level: 1,
+                        experience_level: "mid",
                       })
                     }
                     className="flex items-center gap-2"
@@ -381,46 +383,38 @@ export default function CreateProjectPage() {
 
                         <div className="space-y-2">
                           <Label
-                            htmlFor={`project_roles.${index}.required_experience_level`}
+                            htmlFor={`project_roles.${index}.experience_level`}
                           >
-                            Experience Level (1-5){" "}
+                            Experience Level{" "}
                             <span className="text-red-500">*</span>
                           </Label>
                           <Controller
-                            name={`project_roles.${index}.required_experience_level`}
+                            name={`project_roles.${index}.experience_level`}
                             control={control}
                             render={({ field }) => (
                               <Select
-                                value={field.value?.toString() || "1"}
-                                onValueChange={(value) =>
-                                  field.onChange(parseInt(value))
-                                }
+                                value={field.value || "mid"}
+                                onValueChange={field.onChange}
                               >
                                 <SelectTrigger>
                                   <SelectValue placeholder="Select experience level" />
                                 </SelectTrigger>
                                 <SelectContent>
-                                  <SelectItem value="1">
-                                    1 - Beginner
-                                  </SelectItem>
-                                  <SelectItem value="2">2 - Basic</SelectItem>
-                                  <SelectItem value="3">
-                                    3 - Intermediate
-                                  </SelectItem>
-                                  <SelectItem value="4">
-                                    4 - Advanced
-                                  </SelectItem>
-                                  <SelectItem value="5">5 - Expert</SelectItem>
+                                  {experienceLevels.map((level) => (
+                                    <SelectItem key={level} value={level}>
+                                      {getExperienceLevelText(level)}
+                                    </SelectItem>
+                                  ))}
                                 </SelectContent>
                               </Select>
                             )}
                           />
                           {errors.project_roles?.[index]
-                            ?.required_experience_level && (
+                            ?.experience_level && (
                             <p className="text-sm text-red-500">
                               {
                                 errors.project_roles[index]
-                                  ?.required_experience_level?.message
+                                  ?.experience_level?.message
                               }
                             </p>
                           )}
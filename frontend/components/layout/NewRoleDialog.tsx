@@ -20,7 +20,12 @@ import { Textarea } from '@/components/ui/textarea'
 import { Button } from '@/components/ui/button'
 import { Plus } from 'lucide-react'
 import { Dispatch, SetStateAction, useState } from 'react'
-import { ProjectRolesRequest } from '@/lib/types/project_types'
+import {
+  ExperienceLevel,
+  experienceLevels,
+  ProjectRolesRequest,
+} from '@/lib/types/project_types'
+import { getExperienceLevelText } from './components_helper'
 
 type NewRoleDialogProps = {
   isOpen: boolean
@@ -49,12 +54,12 @@ export function NewRoleDialog({
       newErrors.title = 'Title must be at least 3 characters'
     }
 
-    if (
-      !role.required_experience_level ||
-      role.required_experience_level < 1 ||
-      role.required_experience_level > 5
-    ) {
-      newErrors.required_experience_level = 'Experience level is required'
+    if (!role.experience_level) {
+      newErrors.experience_level = 'Experience level is required'
+    }
+
+    if (role.seats !== undefined && (role.seats < 1 || role.seats > 100)) {
+      newErrors.seats = 'Seats must be between 1 and 100'
     }
 
     setErrors(newErrors)
@@ -140,36 +145,55 @@ export function NewRoleDialog({
           <div className="space-y-2">
             <Label htmlFor="experience">Experience Level</Label>
             <Select
-              value={role.required_experience_level?.toString() || ''}
+              value={role.experience_level || ''}
               onValueChange={(value) => {
                 setRole({
                   ...role,
-                  required_experience_level: parseInt(value),
+                  experience_level: value as ExperienceLevel,
                 })
-                clearError('required_experience_level')
+                clearError('experience_level')
               }}
             >
               <SelectTrigger
                 className={
-                  errors.required_experience_level ? 'border-red-500' : ''
+                  errors.experience_level ? 'border-red-500' : ''
                 }
               >
                 <SelectValue placeholder="Select experience level" />
               </SelectTrigger>
               <SelectContent>
-                <SelectItem value="1">1 - Beginner</SelectItem>
-                <SelectItem value="2">2 - Basic</SelectItem>
-                <SelectItem value="3">3 - Intermediate</SelectItem>
-                <SelectItem value="4">4 - Advanced</SelectItem>
-                <SelectItem value="5">5 - Expert</SelectItem>
+                {experienceLevels.map((level) => (
+                  <SelectItem key={level} value={level}>
+                    {getExperienceLevelText(level)}
+                  </SelectItem>
+                ))}
               </SelectContent>
             </Select>
-            {errors.required_experience_level && (
+            {errors.experience_level && (
               <p className="text-sm text-red-500">
-                {errors.required_experience_level}
+                {errors.experience_level}
               </p>
             )}
           </div>
+
+          <div className="space-y-2">
+            <Label htmlFor="seats">Seats</Label>
+            <Input
+              id="seats"
+              type="number"
+              min={1}
+              max={100}
+              value={role.seats || 1}
+              onChange={(e) => {
+                setRole({ ...role, seats: parseInt(e.target.value) || 1 })
+                clearError('seats')
+              }}
+              className={errors.seats ? 'border-red-500' : ''}
+            />
+            {errors.seats && (
+              <p className="text-sm text-red-500">{errors.seats}</p>
+            )}
+          </div>
         </div>
         <DialogFooter>
           <Button variant="outline" onClick={handleCancel}>
@@ -13,12 +13,16 @@ import {
   SelectTrigger,
   SelectValue,
 } from '@/components/ui/select'
-import { Checkbox } from '@/components/ui/checkbox'
 import { Label } from '@/components/ui/label'
 import { Input } from '@/components/ui/input'
 import { Textarea } from '@/components/ui/textarea'
 import { Button } from '@/components/ui/button'
 import { Dispatch, SetStateAction, useState } from 'react'
+import {
+  ExperienceLevel,
+  experienceLevels,
+} from '@/lib/types/project_types'
+import { getExperienceLevelText } from './components_helper'
 
 type UpdateRoleDialogProps = {
   isOpen: boolean
@@ -47,12 +51,14 @@ export function UpdateRoleDialog({
       newErrors.title = 'Title must be at least 3 characters'
     }
 
-    if (
-      !role?.required_experience_level ||
-      role.required_experience_level < 1 ||
-      role.required_experience_level > 5
-    ) {
-      newErrors.required_experience_level = 'Experience level is required'
+    if (!role?.experience_level) {
+      newErrors.experience_level = 'Experience level is required'
+    }
+
+    if (role?.seats < Math.max(role?.filled_seats || 0, 1)) {
+      newErrors.seats = `At least ${Math.max(role.filled_seats, 1)} seats are needed`
+    } else if (role?.seats > 100) {
+      newErrors.seats = 'At most 100 seats'
     }
 
     setErrors(newErrors)
@@ -133,54 +139,54 @@ export function UpdateRoleDialog({
           <div className="space-y-2">
             <Label htmlFor="edit-experience">Experience Level</Label>
             <Select
-              value={role?.required_experience_level?.toString() || ''}
+              value={role?.experience_level || ''}
               onValueChange={(value) => {
                 setRole({
                   ...role,
-                  required_experience_level: parseInt(value),
+                  experience_level: value as ExperienceLevel,
                 })
-                clearError('required_experience_level')
+                clearError('experience_level')
               }}
             >
               <SelectTrigger
                 className={
-                  errors.required_experience_level ? 'border-red-500' : ''
+                  errors.experience_level ? 'border-red-500' : ''
                 }
               >
                 <SelectValue placeholder="Select experience level" />
               </SelectTrigger>
               <SelectContent>
-                <SelectItem value="1">1 - Beginner</SelectItem>
-                <SelectItem value="2">2 - Basic</SelectItem>
-                <SelectItem value="3">3 - Intermediate</SelectItem>
-                <SelectItem value="4">4 - Advanced</SelectItem>
-                <SelectItem value="5">5 - Expert</SelectItem>
+                {experienceLevels.map((level) => (
+                  <SelectItem key={level} value={level}>
+                    {getExperienceLevelText(level)}
+                  </SelectItem>
+                ))}
               </SelectContent>
             </Select>
-            {errors.required_experience_level && (
+            {errors.experience_level && (
               <p className="text-sm text-red-500">
-                {errors.required_experience_level}
+                {errors.experience_level}
               </p>
             )}
           </div>
 
-          <div className="flex items-center space-x-2">
-            <Checkbox
-              id="edit-is-filled"
-              checked={role?.is_filled || false}
-              onCheckedChange={(checked) =>
-                setRole({
-                  ...role,
-                  is_filled: checked === true,
-                })
-              }
+          <div className="space-y-2">
+            <Label htmlFor="edit-seats">Seats</Label>
+            <Input
+              id="edit-seats"
+              type="number"
+              min={Math.max(role?.filled_seats || 0, 1)}
+              max={100}
+              value={role?.seats || 1}
+              onChange={(e) => {
+                setRole({ ...role, seats: parseInt(e.target.value) || 1 })
+                clearError('seats')
+              }}
+              className={errors.seats ? 'border-red-500' : ''}
             />
-            <Label
-              htmlFor="edit-is-filled"
-              className="text-sm font-medium leading-none peer-disabled:cursor-not-allowed peer-disabled:opacity-70"
-            >
-              Mark this role as filled
-            </Label>
+            {errors.seats && (
+              <p className="text-sm text-red-500">{errors.seats}</p>
+            )}
           </div>
         </div>
         <DialogFooter>
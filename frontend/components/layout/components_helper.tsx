@@ -18,13 +18,11 @@ export const getStageIcon = (stage: string) => {
   return icons[stage] || Lightbulb
 }
 
-export const getExperienceLevelText = (level: number) => {
-  const levels: Record<number, string> = {
-    1: "Beginner",
-    2: "Basic",
-    3: "Intermediate",
-    4: "Advanced",
-    5: "Expert",
+export const getExperienceLevelText = (level: string) => {
+  const levels: Record<string, string> = {
+    junior: "Junior",
+    mid: "Mid-level",
+    senior: "Senior",
   }
   return levels[level] || "Unknown"
 }
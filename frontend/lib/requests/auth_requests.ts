@@ -7,8 +7,8 @@ export async function loginUser(credentials: LoginCredentials): Promise<AuthResp
     const response = await axiosClient.post<AuthResponse>(AUTH_ROUTES.LOGIN, credentials);
     return response.data;
   } catch (error: any) {
-    if (error?.response?.data?.message) {
-      throw new Error(error.response.data.message);
+    if (error?.response?.data?.detail) {
+      throw new Error(error.response.data.detail);
     }
     throw new Error('Failed to login');
   }
@@ -19,8 +19,8 @@ export async function signupUser(credentials: SignupCredentials): Promise<AuthRe
     const response = await axiosClient.post<AuthResponse>(AUTH_ROUTES.SIGNUP, credentials);
     return response.data;
   } catch (error: any) {
-    if (error?.response?.data?.message) {
-      throw new Error(error.response.data.message);
+    if (error?.response?.data?.detail) {
+      throw new Error(error.response.data.detail);
     }
     throw new Error('Failed to sign up');
   }
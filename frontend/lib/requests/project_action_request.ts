@@ -2,9 +2,16 @@ import { useMutation, useQuery, useQueryClient } from '@tanstack/react-query'
 import axiosClient from '../axiosClient'
 import { MessageResponse } from '../types/error_types'
 import {
+  Applicant,
+  ApplicantFilter,
+  ApplicantReview,
+  ApplicationUsage,
+  InviteRequest,
   ProjectActionReplyRequest,
   ProjectActionRequest,
   ProjectRequest,
+  RemoveMemberRequest,
+  ReviewApplicantRequest,
 } from '../types/project_action_types'
 
 const BASE_URL = '/project/request'
@@ -22,6 +29,49 @@ const useGetProjectRequests = (id: string) => {
   })
 }
 
+const getApplicants = async (
+  id: string,
+  filter: ApplicantFilter,
+): Promise<Applicant[]> => {
+  const resp = await axiosClient.get<Applicant[]>(
+    `${BASE_URL}/${id}/applicants`,
+    { params: filter },
+  )
+  return resp.data
+}
+
+const useGetApplicants = (id: string, filter: ApplicantFilter = {}) => {
+  return useQuery({
+    queryKey: ['applicants', id, filter],
+    queryFn: () => getApplicants(id, filter),
+    enabled: !!id,
+  })
+}
+
+const reviewApplicant = async ({
+  requestId,
+  review,
+}: {
+  requestId: number
+  review: ReviewApplicantRequest
+}) => {
+  const resp = await axiosClient.patch<ApplicantReview>(
+    `${BASE_URL}/applicants/${requestId}`,
+    review,
+  )
+  return resp.data
+}
+
+const useReviewApplicant = () => {
+  const queryClient = useQueryClient()
+  return useMutation({
+    mutationFn: reviewApplicant,
+    onSuccess: () => {
+      queryClient.invalidateQueries({ queryKey: ['applicants'] })
+    },
+  })
+}
+
 const applyRole = async (req: ProjectActionRequest) => {
   const resp = await axiosClient.post<MessageResponse>(`${BASE_URL}/apply`, req)
   return resp.data
@@ -33,6 +83,7 @@ const useApplyRole = () => {
     mutationFn: applyRole,
     onSuccess: () => {
       queryClient.invalidateQueries({ queryKey: ['project_requests'] })
+      queryClient.invalidateQueries({ queryKey: ['application_usage'] })
     },
   })
 }
@@ -50,6 +101,7 @@ const useCancelRoleRequest = () => {
     mutationFn: cancelRoleRequest,
     onSuccess: () => {
       queryClient.invalidateQueries({ queryKey: ['project_requests'] })
+      queryClient.invalidateQueries({ queryKey: ['application_usage'] })
     },
   })
 }
@@ -89,10 +141,107 @@ const useReplyRoleRequest = () => {
   })
 }
 
+const removeMember = async ({
+  requestId,
+  req,
+}: {
+  requestId: number
+  req: RemoveMemberRequest
+}) => {
+  const resp = await axiosClient.delete<MessageResponse>(
+    `${BASE_URL}/members/${requestId}`,
+    { data: req } as any,
+  )
+  return resp.data
+}
+
+const useRemoveMember = () => {
+  const queryClient = useQueryClient()
+  return useMutation({
+    mutationFn: removeMember,
+    onSuccess: () => {
+      queryClient.invalidateQueries({ queryKey: ['projects'] })
+      queryClient.invalidateQueries({ queryKey: ['project_requests'] })
+      queryClient.invalidateQueries({ queryKey: ['applicants'] })
+    },
+  })
+}
+
+const inviteToRole = async (req: InviteRequest) => {
+  const resp = await axiosClient.post<ProjectRequest>(`${BASE_URL}/invite`, req)
+  return resp.data
+}
+
+const useInviteToRole = () => {
+  const queryClient = useQueryClient()
+  return useMutation({
+    mutationFn: inviteToRole,
+    onSuccess: () => {
+      queryClient.invalidateQueries({ queryKey: ['project_requests'] })
+    },
+  })
+}
+
+const getApplicationUsage = async (): Promise<ApplicationUsage> => {
+  const resp = await axiosClient.get<ApplicationUsage>(`${BASE_URL}/usage`)
+  return resp.data
+}
+
+const useGetApplicationUsage = () => {
+  return useQuery({
+    queryKey: ['application_usage'],
+    queryFn: getApplicationUsage,
+  })
+}
+
+const getInvitations = async (): Promise<ProjectRequest[]> => {
+  const resp = await axiosClient.get<ProjectRequest[]>(`${BASE_URL}/invitations`)
+  return resp.data
+}
+
+const useGetInvitations = () => {
+  return useQuery({
+    queryKey: ['invitations'],
+    queryFn: getInvitations,
+  })
+}
+
+const respondToInvitation = async ({
+  id,
+  accept,
+}: {
+  id: number
+  accept: boolean
+}) => {
+  const action = accept ? 'accept' : 'decline'
+  const resp = await axiosClient.put<MessageResponse>(
+    `${BASE_URL}/invitations/${id}/${action}`,
+  )
+  return resp.data
+}
+
+const useRespondToInvitation = () => {
+  const queryClient = useQueryClient()
+  return useMutation({
+    mutationFn: respondToInvitation,
+    onSuccess: () => {
+      queryClient.invalidateQueries({ queryKey: ['invitations'] })
+      queryClient.invalidateQueries({ queryKey: ['project_requests'] })
+    },
+  })
+}
+
 export {
   useApplyRole,
   useCancelRoleRequest,
   useWithdrawRoleRequest,
   useReplyRoleRequest,
   useGetProjectRequests,
+  useInviteToRole,
+  useGetInvitations,
+  useRespondToInvitation,
+  useGetApplicants,
+  useReviewApplicant,
+  useRemoveMember,
+  useGetApplicationUsage,
 }
@@ -4,6 +4,7 @@ import type {
   Category,
   CreateProjectRequest,
   Language,
+  PatchProjectRequest,
   ProjectResponse,
   Technology,
   Types,
@@ -142,6 +143,28 @@ export const useUpdateProject = (projectId: string) => {
   })
 }
 
+const patchProject = async (
+  projectId: string,
+  projectData: PatchProjectRequest,
+) => {
+  const response = await axiosClient.patch<ProjectResponse>(
+    `/projects/${projectId}`,
+    projectData,
+  )
+  return response.data
+}
+
+export const usePatchProject = (projectId: string) => {
+  const queryClient = useQueryClient()
+  return useMutation({
+    mutationFn: (projectData: PatchProjectRequest) =>
+      patchProject(projectId, projectData),
+    onSuccess: () => {
+      queryClient.invalidateQueries({ queryKey: ['project', projectId] })
+    },
+  })
+}
+
 const deleteProject = async (id: string) => {
   const response = await axiosClient.delete(`/projects/${id}`)
   return response.data
@@ -1,6 +1,11 @@
-import { useMutation, useQueryClient } from '@tanstack/react-query'
+import { useMutation, useQuery, useQueryClient } from '@tanstack/react-query'
 import axiosClient from '../axiosClient'
-import { ProjectRoles, ProjectRolesRequest } from '../types/project_types'
+import {
+  ProjectRoles,
+  ProjectRolesRequest,
+  RoleQuestion,
+  RoleQuestionRequest,
+} from '../types/project_types'
 
 const newProjectRole = async (data: ProjectRolesRequest) => {
   const resp = await axiosClient.post<ProjectRoles>('/project/roles', data)
@@ -58,4 +63,48 @@ const useDelteProjectRole = (projectId: string) => {
   })
 }
 
-export { useNewProjectRole, useUpdateProjectRole }
+const getRoleQuestions = async (roleId: number) => {
+  const resp = await axiosClient.get<RoleQuestion[]>(
+    `/project/roles/${roleId}/questions`,
+  )
+  return resp.data
+}
+
+const useRoleQuestions = (roleId: number) => {
+  return useQuery({
+    queryKey: ['roleQuestions', roleId],
+    queryFn: () => getRoleQuestions(roleId),
+    enabled: !!roleId,
+  })
+}
+
+const newRoleQuestion = async ({
+  roleId,
+  data,
+}: {
+  roleId: number
+  data: RoleQuestionRequest
+}) => {
+  const resp = await axiosClient.post<RoleQuestion>(
+    `/project/roles/${roleId}/questions`,
+    data,
+  )
+  return resp.data
+}
+
+const useNewRoleQuestion = (roleId: number) => {
+  const queryClient = useQueryClient()
+  return useMutation({
+    mutationFn: newRoleQuestion,
+    onSuccess: () => {
+      queryClient.invalidateQueries({ queryKey: ['roleQuestions', roleId] })
+    },
+  })
+}
+
+export {
+  useNewProjectRole,
+  useUpdateProjectRole,
+  useRoleQuestions,
+  useNewRoleQuestion,
+}
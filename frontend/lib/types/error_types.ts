@@ -1,3 +1,22 @@
 export interface MessageResponse {
     message: string;
   }
+
+// One failed validation rule, keyed by the JSON name of the field
+export interface FieldError {
+  field: string;
+  rule: string;
+  message: string;
+}
+
+// RFC 7807 problem details returned by the API for every error
+export interface ProblemDetails {
+  type: string;
+  title: string;
+  status: number;
+  detail?: string;
+  instance?: string;
+  code: string;
+  request_id?: string;
+  errors?: FieldError[];
+}
@@ -1,11 +1,56 @@
+export type ProjectRequestStatus =
+  | 'pending'
+  | 'accepted'
+  | 'rejected'
+  | 'cancelled'
+  | 'withdrawn'
+  | 'removed'
+  | 'expired'
+  // waiting for a seat of a filled role to reopen
+  | 'waitlisted'
+
+export type ProjectRequestEvent = {
+  id: number
+  request_id: number
+  actor_id: number | null
+  from_status: ProjectRequestStatus | null
+  to_status: ProjectRequestStatus
+  note?: string
+  created_at: string
+}
+
+export type RequestAnswer = {
+  id: number
+  request_id: number
+  question_id: number | null
+  prompt: string
+  answer: string
+}
+
+export type ProjectRequestDirection = 'application' | 'invitation'
+
 export type ProjectRequest = {
   id: number
   project_id: number
-  user_id: number
+  // null for invitations to an email without an account
+  user_id: number | null
   role_id: number
-  status: string
+  status: ProjectRequestStatus
   created_at: string
   updated_at: string
+  direction: ProjectRequestDirection
+  invitee_email?: string
+  invited_by?: number
+  expires_at?: string
+  message?: string
+  links: string[] | null
+  answers: RequestAnswer[]
+  timeline: ProjectRequestEvent[]
+}
+
+export type AnswerRequest = {
+  question_id: number
+  answer: string
 }
 
 export type ProjectActionRequest = {
@@ -13,9 +58,93 @@ export type ProjectActionRequest = {
   user_id?: number
   role_id: number
   status?: string
+  message?: string
+  answers?: AnswerRequest[]
+  links?: string[]
+}
+
+export type InviteRequest = {
+  project_id: number
+  role_id: number
+  user_id?: number
+  email?: string
+  message?: string
+}
+
+export type RemoveMemberRequest = {
+  reason?: string
 }
 
 export type ProjectActionReplyRequest = {
   request_id: number
   accepted: boolean
 }
+
+export type ApplicantProfile = {
+  id: number
+  name: string
+  email: string
+  profile_picture?: string
+  availability: boolean
+}
+
+export type ApplicantSkill = {
+  category_id?: number
+  technology_id?: number
+  technology?: string
+  language_id?: number
+  language?: string
+  proficiency_level?: string
+}
+
+// private to the project owner
+export type ApplicantReview = {
+  shortlisted: boolean
+  tags: string[]
+  rating?: number
+  note?: string
+  updated_at?: string
+}
+
+export type Applicant = ProjectRequest & {
+  // null for invitations to an email without an account
+  applicant: ApplicantProfile | null
+  skills: ApplicantSkill[]
+  review: ApplicantReview
+  match_score: number
+}
+
+export type ApplicantFilter = {
+  role_id?: number
+  status?: ProjectRequestStatus
+  shortlisted?: boolean
+  tag?: string
+  min_rating?: number
+  min_score?: number
+  sort?: 'created_at' | 'match_score' | 'rating'
+  order?: 'asc' | 'desc'
+}
+
+// rating 0 and an empty note clear them
+export type ReviewApplicantRequest = {
+  shortlisted?: boolean
+  tags?: string[]
+  rating?: number
+  note?: string
+}
+
+// a role the user was rejected for recently and cannot apply to until then
+export type ApplyCooldown = {
+  project_id: number
+  role_id: number
+  rejected_at: string
+  until: string
+}
+
+// limits of 0 are unlimited
+export type ApplicationUsage = {
+  open_applications: number
+  max_open_applications: number
+  max_open_per_role: number
+  cooldowns: ApplyCooldown[]
+}
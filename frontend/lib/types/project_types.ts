@@ -1,12 +1,33 @@
 import { User } from './auth_types'
 import { z } from 'zod'
 
+export const experienceLevels = ['junior', 'mid', 'senior'] as const
+export type ExperienceLevel = (typeof experienceLevels)[number]
+
+// a technology or language a role asks for; not required means nice to have
+export interface RoleSkill {
+  id: number
+  name: string
+  required: boolean
+}
+
+export interface RoleSkillRequest {
+  id: number
+  required: boolean
+}
+
 export interface ProjectRolesRequest {
   title: string
   project_id?: number
   description?: string
-  required_experience_level: number
-  is_filled?: boolean
+  // unset means mid on create and unchanged on update
+  experience_level?: ExperienceLevel
+  // 0 or unset means 1 on create and unchanged on update
+  seats?: number
+  // replace the role's skills; unset keeps them
+  technologies?: RoleSkillRequest[]
+  languages?: RoleSkillRequest[]
+  version?: number
 }
 
 export interface ProjectRoles {
@@ -14,8 +35,33 @@ export interface ProjectRoles {
   project_id: number
   title: string
   description: string
-  required_experience_level: string
+  experience_level: ExperienceLevel
+  // true once every seat is filled
   is_filled: boolean
+  seats: number
+  filled_seats: number
+  seats_remaining: number
+  version: number
+  technologies: RoleSkill[]
+  languages: RoleSkill[]
+}
+
+export interface RoleQuestion {
+  id: number
+  role_id: number
+  prompt: string
+  kind: 'text' | 'choice'
+  options?: string[]
+  required: boolean
+  position: number
+}
+
+export interface RoleQuestionRequest {
+  prompt: string
+  kind: 'text' | 'choice'
+  options?: string[]
+  required: boolean
+  position?: number
 }
 
 export interface Category {
@@ -52,10 +98,12 @@ export interface ProjectResponse {
   technologies: Technology[]
   languages: Language[]
   project_roles: ProjectRoles[]
+  version: number
 }
 
 export interface UpdateProjectRequest {
   id: number
+  version: number
   title: string
   description: string
   goals?: string | null
@@ -66,6 +114,24 @@ export interface UpdateProjectRequest {
   languages: number[]
 }
 
+export interface LinkPatch {
+  add?: number[]
+  remove?: number[]
+}
+
+// Partial update, omitted fields are left unchanged
+export interface PatchProjectRequest {
+  version: number
+  title?: string
+  description?: string
+  goals?: string
+  stage?: string
+  category_id?: number
+  project_type?: LinkPatch
+  technologies?: LinkPatch
+  languages?: LinkPatch
+}
+
 export interface CreateProjectRequest {
   title: string
   description: string
@@ -103,10 +169,9 @@ export const createProjectSchema = z.object({
       z.object({
         title: z.string().min(1, 'Role title is required'),
         description: z.string().optional(),
-        required_experience_level: z
-          .number()
-          .min(1, 'Experience level must be between 1 and 5')
-          .max(5, 'Experience level must be between 1 and 5'),
+        experience_level: z.enum(experienceLevels, {
+          required_error: 'Experience level is required',
+        }),
       }),
     )
     .min(1, 'At least one project role is required'),